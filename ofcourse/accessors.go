@@ -0,0 +1,377 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingKey means a key was not found in the source or params.
+	ErrMissingKey = errors.New("key is missing")
+)
+
+// KeyError is returned by the typed accessors of Source and Params when a
+// key is missing or its value has the wrong type.
+type KeyError struct {
+	// Kind is either "source" or "params".
+	Kind string
+	// Key is the key or path that was requested.
+	Key string
+	// Err describes what went wrong.
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("%s key %q: %s", e.Kind, e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error, which is ErrMissingKey for missing keys.
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalJSON decodes numbers in the source as json.Number, so that large
+// integers are not converted to float64 and lose precision.
+func (s *Source) UnmarshalJSON(data []byte) error {
+	config, err := unmarshalConfig(data)
+	if err != nil {
+		return err
+	}
+	*s = config
+	return nil
+}
+
+// UnmarshalJSON decodes numbers in the params as json.Number, so that large
+// integers are not converted to float64 and lose precision.
+func (p *Params) UnmarshalJSON(data []byte) error {
+	config, err := unmarshalConfig(data)
+	if err != nil {
+		return err
+	}
+	*p = config
+	return nil
+}
+
+func unmarshalConfig(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var config map[string]interface{}
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// String returns the value of key as a string.
+func (s Source) String(key string) (string, error) {
+	return configString("source", s, key)
+}
+
+// Bool returns the value of key as a bool.
+func (s Source) Bool(key string) (bool, error) {
+	return configBool("source", s, key)
+}
+
+// Int returns the value of key as an int64. The value must be a whole number.
+func (s Source) Int(key string) (int64, error) {
+	return configInt("source", s, key)
+}
+
+// Duration returns the value of key as a time.Duration. The value may be a string
+// such as "1m30s" as accepted by time.ParseDuration, or a whole number of seconds.
+func (s Source) Duration(key string) (time.Duration, error) {
+	return configDuration("source", s, key)
+}
+
+// StringSlice returns the value of key as a []string.
+func (s Source) StringSlice(key string) ([]string, error) {
+	return configStringSlice("source", s, key)
+}
+
+// Map returns the value of key as a map[string]interface{}.
+func (s Source) Map(key string) (map[string]interface{}, error) {
+	return configMap("source", s, key)
+}
+
+// Path returns the value at a dot separated path such as "a.b.c", where each
+// element is a key of a nested map or an index of a nested array.
+func (s Source) Path(path string) (interface{}, error) {
+	return configPath("source", s, path)
+}
+
+// String returns the value of key as a string.
+func (p Params) String(key string) (string, error) {
+	return configString("params", p, key)
+}
+
+// Bool returns the value of key as a bool.
+func (p Params) Bool(key string) (bool, error) {
+	return configBool("params", p, key)
+}
+
+// Int returns the value of key as an int64. The value must be a whole number.
+func (p Params) Int(key string) (int64, error) {
+	return configInt("params", p, key)
+}
+
+// Duration returns the value of key as a time.Duration. The value may be a string
+// such as "1m30s" as accepted by time.ParseDuration, or a whole number of seconds.
+func (p Params) Duration(key string) (time.Duration, error) {
+	return configDuration("params", p, key)
+}
+
+// StringSlice returns the value of key as a []string.
+func (p Params) StringSlice(key string) ([]string, error) {
+	return configStringSlice("params", p, key)
+}
+
+// Map returns the value of key as a map[string]interface{}.
+func (p Params) Map(key string) (map[string]interface{}, error) {
+	return configMap("params", p, key)
+}
+
+// Path returns the value at a dot separated path such as "a.b.c", where each
+// element is a key of a nested map or an index of a nested array.
+func (p Params) Path(path string) (interface{}, error) {
+	return configPath("params", p, path)
+}
+
+func configValue(kind string, config map[string]interface{}, key string) (interface{}, error) {
+	value, ok := config[key]
+	if !ok {
+		return nil, &KeyError{Kind: kind, Key: key, Err: ErrMissingKey}
+	}
+	return value, nil
+}
+
+func typeError(kind, key, expected string, value interface{}) error {
+	return &KeyError{
+		Kind: kind,
+		Key:  key,
+		Err:  fmt.Errorf("expected %s but got %s", expected, typeName(value)),
+	}
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case json.Number, int, int8, int16, int32, int64, uint, uint8, uint16,
+		uint32, uint64, float32, float64:
+		return "number"
+	case []interface{}, []string:
+		return "array"
+	case map[string]interface{}, Source, Params:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func configString(kind string, config map[string]interface{}, key string) (string, error) {
+	value, err := configValue(kind, config, key)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", typeError(kind, key, "string", value)
+	}
+	return s, nil
+}
+
+func configBool(kind string, config map[string]interface{}, key string) (bool, error) {
+	value, err := configValue(kind, config, key)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, typeError(kind, key, "bool", value)
+	}
+	return b, nil
+}
+
+func configInt(kind string, config map[string]interface{}, key string) (int64, error) {
+	value, err := configValue(kind, config, key)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := toInt(value)
+	if !ok {
+		return 0, typeError(kind, key, "integer", value)
+	}
+	return i, nil
+}
+
+func toInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToInt(f)
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), v <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float32:
+		return floatToInt(float64(v))
+	case float64:
+		return floatToInt(v)
+	default:
+		return 0, false
+	}
+}
+
+func floatToInt(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+func configDuration(kind string, config map[string]interface{}, key string) (time.Duration, error) {
+	value, err := configValue(kind, config, key)
+	if err != nil {
+		return 0, err
+	}
+	if s, ok := value.(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, &KeyError{Kind: kind, Key: key, Err: err}
+		}
+		return d, nil
+	}
+	seconds, ok := toInt(value)
+	if !ok {
+		return 0, typeError(kind, key, "duration", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func configStringSlice(kind string, config map[string]interface{}, key string) ([]string, error) {
+	value, err := configValue(kind, config, key)
+	if err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, typeError(kind, fmt.Sprintf("%s.%d", key, i), "string", item)
+			}
+			strs[i] = s
+		}
+		return strs, nil
+	default:
+		return nil, typeError(kind, key, "array of strings", value)
+	}
+}
+
+func configMap(kind string, config map[string]interface{}, key string) (map[string]interface{}, error) {
+	value, err := configValue(kind, config, key)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := toMap(value)
+	if !ok {
+		return nil, typeError(kind, key, "object", value)
+	}
+	return m, nil
+}
+
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case Source:
+		return v, true
+	case Params:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+func configPath(kind string, config map[string]interface{}, path string) (interface{}, error) {
+	var current interface{} = config
+	elements := strings.Split(path, ".")
+	for i, element := range elements {
+		traversed := strings.Join(elements[:i+1], ".")
+		switch v := current.(type) {
+		case []interface{}:
+			index, err := strconv.Atoi(element)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, &KeyError{Kind: kind, Key: traversed, Err: ErrMissingKey}
+			}
+			current = v[index]
+		default:
+			m, ok := toMap(current)
+			if !ok {
+				parent := strings.Join(elements[:i], ".")
+				return nil, typeError(kind, parent, "object or array", current)
+			}
+			value, ok := m[element]
+			if !ok {
+				return nil, &KeyError{Kind: kind, Key: traversed, Err: ErrMissingKey}
+			}
+			current = value
+		}
+	}
+	return current, nil
+}
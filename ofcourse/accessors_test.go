@@ -0,0 +1,134 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SourceUnmarshalJSON(t *testing.T) {
+	var input CheckInput
+	err := json.Unmarshal([]byte(`{"source":{"id":9007199254740993,"ratio":0.5}}`), &input)
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("9007199254740993"), input.Source["id"])
+
+	id, err := input.Source.Int("id")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+
+	_, err = input.Source.Int("ratio")
+	assert.EqualError(t, err, `source key "ratio": expected integer but got number`)
+
+	var params Params
+	err = json.Unmarshal([]byte(`null`), &params)
+	assert.Nil(t, err)
+	assert.Nil(t, params)
+}
+
+func Test_SourceAccessors(t *testing.T) {
+	source := Source{
+		"name":     "noop",
+		"enabled":  true,
+		"count":    json.Number("3"),
+		"literal":  3,
+		"whole":    3.0,
+		"interval": "1m30s",
+		"timeout":  json.Number("10"),
+		"tags":     []interface{}{"a", "b"},
+		"mixed":    []interface{}{"a", 1},
+		"labels":   map[string]interface{}{"team": "x"},
+	}
+
+	s, err := source.String("name")
+	assert.Nil(t, err)
+	assert.Equal(t, "noop", s)
+	_, err = source.String("enabled")
+	assert.EqualError(t, err, `source key "enabled": expected string but got bool`)
+	_, err = source.String("nope")
+	assert.EqualError(t, err, `source key "nope": key is missing`)
+	assert.Equal(t, ErrMissingKey, err.(*KeyError).Unwrap())
+
+	b, err := source.Bool("enabled")
+	assert.Nil(t, err)
+	assert.True(t, b)
+	_, err = source.Bool("name")
+	assert.EqualError(t, err, `source key "name": expected bool but got string`)
+
+	for _, key := range []string{"count", "literal", "whole"} {
+		i, err := source.Int(key)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), i)
+	}
+
+	d, err := source.Duration("interval")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, d)
+	d, err = source.Duration("timeout")
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Second, d)
+	_, err = source.Duration("name")
+	assert.EqualError(t, err, `source key "name": time: invalid duration "noop"`)
+
+	tags, err := source.StringSlice("tags")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, tags)
+	_, err = source.StringSlice("mixed")
+	assert.EqualError(t, err, `source key "mixed.1": expected string but got number`)
+
+	labels, err := source.Map("labels")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"team": "x"}, labels)
+	_, err = source.Map("tags")
+	assert.EqualError(t, err, `source key "tags": expected object but got array`)
+}
+
+func Test_ParamsPath(t *testing.T) {
+	params := Params{
+		"a": map[string]interface{}{
+			"b": []interface{}{
+				map[string]interface{}{"c": "d"},
+			},
+		},
+	}
+	tests := []struct {
+		path  string
+		value interface{}
+		err   string
+	}{
+		{"a.b.0.c", "d", ""},
+		{"a.b.0", map[string]interface{}{"c": "d"}, ""},
+		{"a.x", nil, `params key "a.x": key is missing`},
+		{"a.b.1", nil, `params key "a.b.1": key is missing`},
+		{"a.b.0.c.d", nil, `params key "a.b.0.c": expected object or array but got string`},
+	}
+	for _, test := range tests {
+		value, err := params.Path(test.path)
+		if test.err == "" {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, test.err)
+		}
+		assert.Equal(t, test.value, value)
+	}
+}
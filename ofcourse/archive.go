@@ -0,0 +1,585 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveOptions configures the creation and extraction of archives.
+type ArchiveOptions struct {
+	// Include contains patterns for the files to include, as accepted by path.Match.
+	// If empty, all files are included. A pattern is matched against the slash
+	// separated relative path of a file, its base name, and each of its parent
+	// directories, so "bin" includes everything in the top level bin directory.
+	Include []string
+	// Exclude contains patterns for files and directories to leave out, matched the
+	// same way as Include. Exclude takes precedence over Include.
+	Exclude []string
+	// StripComponents removes this number of leading path components from the
+	// names of entries when extracting. Entries with fewer components are skipped.
+	StripComponents int
+}
+
+// ExtractTar extracts a tar archive into dest, preserving permissions, symbolic links,
+// and hard links. Entries whose names or link targets would be outside of dest are
+// rejected with an error.
+func ExtractTar(r io.Reader, dest string, options ArchiveOptions) error {
+	x, err := newExtractor(dest, options)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		mode := header.FileInfo().Mode()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = x.dir(header.Name, mode)
+		case tar.TypeReg:
+			err = x.file(header.Name, mode, tr)
+		case tar.TypeSymlink:
+			err = x.symlink(header.Name, header.Linkname)
+		case tar.TypeLink:
+			err = x.link(header.Name, header.Linkname)
+		case tar.TypeXGlobalHeader:
+			continue
+		default:
+			err = fmt.Errorf("archive entry %q has unsupported type %q", header.Name, header.Typeflag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return x.finish()
+}
+
+// ExtractTarGz extracts a gzip compressed tar archive into dest, as with ExtractTar.
+func ExtractTarGz(r io.Reader, dest string, options ArchiveOptions) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	return ExtractTar(gz, dest, options)
+}
+
+// ExtractZip extracts a zip archive into dest, as with ExtractTar. Since the zip format
+// keeps its index at the end, the archive is first copied to a temporary file unless
+// r is an *os.File.
+func ExtractZip(r io.Reader, dest string, options ArchiveOptions) error {
+	fd, ok := r.(*os.File)
+	if !ok {
+		tmp, err := ioutil.TempFile("", "ofcourse-zip")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		if _, err := io.Copy(tmp, r); err != nil {
+			return err
+		}
+		fd = tmp
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(fd, info.Size())
+	if err != nil {
+		return err
+	}
+
+	x, err := newExtractor(dest, options)
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		if err := x.zipFile(f); err != nil {
+			return err
+		}
+	}
+	return x.finish()
+}
+
+type extractor struct {
+	dest     string
+	realDest string
+	options  ArchiveOptions
+	dirModes map[string]os.FileMode
+	symlinks []string
+}
+
+func newExtractor(dest string, options ArchiveOptions) (*extractor, error) {
+	if err := validatePatterns(options.Include); err != nil {
+		return nil, err
+	}
+	if err := validatePatterns(options.Exclude); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return nil, err
+	}
+	realDest, err := filepath.EvalSymlinks(dest)
+	if err != nil {
+		return nil, err
+	}
+	return &extractor{
+		dest:     dest,
+		realDest: realDest,
+		options:  options,
+		dirModes: map[string]os.FileMode{},
+	}, nil
+}
+
+// target returns the path in dest for an entry name after stripping components,
+// or an empty string if the entry should be skipped.
+func (x *extractor) target(name string, isDir bool) (string, string, error) {
+	slashed := strings.Replace(name, "\\", "/", -1)
+	if path.IsAbs(slashed) || filepath.IsAbs(name) {
+		return "", "", fmt.Errorf("archive entry %q has an absolute path", name)
+	}
+	cleaned := path.Clean(slashed)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", "", fmt.Errorf("archive entry %q is outside of the destination", name)
+	}
+	components := strings.Split(cleaned, "/")
+	if cleaned == "." || len(components) <= x.options.StripComponents {
+		return "", "", nil
+	}
+	rel := strings.Join(components[x.options.StripComponents:], "/")
+
+	if matchesAnyOrParent(x.options.Exclude, rel) {
+		return "", "", nil
+	}
+	if !isDir && len(x.options.Include) > 0 && !matchesAnyOrParent(x.options.Include, rel) {
+		return "", "", nil
+	}
+
+	target := filepath.Join(x.dest, filepath.FromSlash(rel))
+	if err := x.checkParent(target); err != nil {
+		return "", "", err
+	}
+	return target, rel, nil
+}
+
+// checkParent ensures that the parent directory of target is within the destination
+// after resolving any symbolic links, and then creates it if it does not exist, so
+// that no directories are created outside of the destination.
+func (x *extractor) checkParent(target string) error {
+	parent := filepath.Dir(target)
+	rel, err := filepath.Rel(x.dest, parent)
+	if err != nil {
+		return err
+	}
+	realParent, err := resolveSymlink(x.realDest, rel, maxSymlinkHops)
+	if err != nil {
+		return err
+	}
+	if !within(x.realDest, realParent) {
+		return fmt.Errorf("archive entry %s is outside of the destination", target)
+	}
+	return os.MkdirAll(parent, 0755)
+}
+
+func within(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (x *extractor) dir(name string, mode os.FileMode) error {
+	target, _, err := x.target(name, true)
+	if err != nil || target == "" {
+		return err
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return err
+	}
+	x.dirModes[target] = mode.Perm()
+	return nil
+}
+
+func (x *extractor) file(name string, mode os.FileMode, r io.Reader) error {
+	target, _, err := x.target(name, false)
+	if err != nil || target == "" {
+		return err
+	}
+	if err := removeExisting(target); err != nil {
+		return err
+	}
+	fd, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fd, r); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(target, mode.Perm())
+}
+
+func (x *extractor) symlink(name, linkname string) error {
+	target, rel, err := x.target(name, false)
+	if err != nil || target == "" {
+		return err
+	}
+	slashed := strings.Replace(linkname, "\\", "/", -1)
+	resolved := path.Clean(path.Join(path.Dir(rel), slashed))
+	if path.IsAbs(slashed) || resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Errorf("archive entry %q links to %q outside of the destination", name, linkname)
+	}
+	if err := removeExisting(target); err != nil {
+		return err
+	}
+	if err := os.Symlink(filepath.FromSlash(slashed), target); err != nil {
+		return err
+	}
+	x.symlinks = append(x.symlinks, target)
+	return x.checkSymlink(target, name, linkname)
+}
+
+// maxSymlinkHops limits how many symbolic links are followed when resolving a link.
+const maxSymlinkHops = 255
+
+// checkSymlink resolves the symbolic link at target by following any links it
+// passes through, and removes it if it points outside of the destination. A link
+// may look safe as a string but escape through another link, such as one whose
+// target contains ".." and is extracted later, so all links are checked again
+// when extraction finishes.
+func (x *extractor) checkSymlink(target, name, linkname string) error {
+	info, err := os.Lstat(target)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		// Replaced by a later entry.
+		return nil
+	}
+	realParent, err := filepath.EvalSymlinks(filepath.Dir(target))
+	if err != nil {
+		return err
+	}
+	resolved, err := resolveSymlink(realParent, filepath.Base(target), maxSymlinkHops)
+	if err == nil && within(x.realDest, resolved) {
+		return nil
+	}
+	if err := os.Remove(target); err != nil {
+		return err
+	}
+	return fmt.Errorf("archive entry %q links to %q outside of the destination", name, linkname)
+}
+
+// resolveSymlink returns the path that name, relative to the directory dir which
+// contains no symbolic links, refers to after following symbolic links. Components
+// which do not exist are joined without resolving them.
+func resolveSymlink(dir, name string, hops int) (string, error) {
+	current := dir
+	components := strings.Split(filepath.ToSlash(name), "/")
+	for i, component := range components {
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+			continue
+		}
+		next := filepath.Join(current, component)
+		info, err := os.Lstat(next)
+		if err != nil {
+			rest := filepath.FromSlash(strings.Join(components[i+1:], "/"))
+			return filepath.Join(next, rest), nil
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			current = next
+			continue
+		}
+		if hops == 0 {
+			return "", fmt.Errorf("too many levels of symbolic links in %s", next)
+		}
+		linkname, err := os.Readlink(next)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(linkname) {
+			current, err = resolveSymlink(filepath.VolumeName(linkname)+string(filepath.Separator),
+				linkname, hops-1)
+		} else {
+			current, err = resolveSymlink(current, linkname, hops-1)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return current, nil
+}
+
+func (x *extractor) link(name, linkname string) error {
+	target, _, err := x.target(name, false)
+	if err != nil || target == "" {
+		return err
+	}
+	source, _, err := x.target(linkname, false)
+	if err != nil {
+		return err
+	}
+	if source == "" {
+		return fmt.Errorf("archive entry %q links to %q which was not extracted", name, linkname)
+	}
+	if err := removeExisting(target); err != nil {
+		return err
+	}
+	if err := os.Link(source, target); err != nil {
+		return err
+	}
+	// A hard link to a symbolic link is itself a symbolic link, which may point
+	// elsewhere from its new directory.
+	info, err := os.Lstat(target)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return err
+	}
+	x.symlinks = append(x.symlinks, target)
+	return x.checkSymlink(target, name, linkname)
+}
+
+func (x *extractor) zipFile(f *zip.File) error {
+	mode := f.Mode()
+	switch {
+	case mode.IsDir():
+		return x.dir(f.Name, mode)
+	case mode&os.ModeSymlink != 0:
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		linkname, err := ioutil.ReadAll(io.LimitReader(rc, 4096))
+		if err != nil {
+			return err
+		}
+		return x.symlink(f.Name, string(linkname))
+	case mode.IsRegular():
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		return x.file(f.Name, mode, rc)
+	default:
+		return fmt.Errorf("archive entry %q has unsupported mode %s", f.Name, mode)
+	}
+}
+
+// finish checks symbolic links again and applies directory permissions once all
+// entries have been extracted, so that read only directories do not prevent their
+// contents from being written.
+func (x *extractor) finish() error {
+	for _, target := range x.symlinks {
+		linkname, err := os.Readlink(target)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(x.dest, target)
+		if err != nil {
+			return err
+		}
+		if err := x.checkSymlink(target, filepath.ToSlash(rel), linkname); err != nil {
+			return err
+		}
+	}
+	for dir, mode := range x.dirModes {
+		if err := os.Chmod(dir, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeExisting(target string) error {
+	info, err := os.Lstat(target)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("cannot replace directory %s", target)
+	}
+	return os.Remove(target)
+}
+
+// matchesAnyOrParent reports whether a slash separated relative path, its base name,
+// or any of its parent directories match any of the patterns.
+func matchesAnyOrParent(patterns []string, rel string) bool {
+	for p := rel; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		if matchesAny(patterns, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateTar writes a tar archive of src, which may be a file or a directory, to w.
+// Entry names are relative to src, or the base name if src is a file. Permissions and
+// symbolic links are preserved, and symbolic links are not followed.
+func CreateTar(w io.Writer, src string, options ArchiveOptions) error {
+	tw := tar.NewWriter(w)
+	err := walkArchive(src, options, func(file, name string, info os.FileInfo) error {
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			var err error
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			return copyFile(tw, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// CreateTarGz writes a gzip compressed tar archive of src to w, as with CreateTar.
+func CreateTarGz(w io.Writer, src string, options ArchiveOptions) error {
+	gz := gzip.NewWriter(w)
+	if err := CreateTar(gz, src, options); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// CreateZip writes a zip archive of src to w, as with CreateTar.
+func CreateZip(w io.Writer, src string, options ArchiveOptions) error {
+	zw := zip.NewWriter(w)
+	err := walkArchive(src, options, func(file, name string, info os.FileInfo) error {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(file)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(fw, filepath.ToSlash(link))
+			return err
+		case info.Mode().IsRegular():
+			return copyFile(fw, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func walkArchive(src string, options ArchiveOptions,
+	add func(file, name string, info os.FileInfo) error) error {
+	if err := validatePatterns(options.Include); err != nil {
+		return err
+	}
+	if err := validatePatterns(options.Exclude); err != nil {
+		return err
+	}
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return add(src, filepath.Base(src), info)
+	}
+
+	return filepath.Walk(src, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchesAnyOrParent(options.Exclude, rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() && len(options.Include) > 0 && !matchesAnyOrParent(options.Include, rel) {
+			return nil
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() && info.Mode()&os.ModeSymlink == 0 {
+			return fmt.Errorf("cannot archive %s with mode %s", file, info.Mode())
+		}
+		return add(file, rel, info)
+	})
+}
+
+func copyFile(w io.Writer, file string) error {
+	fd, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	_, err = io.Copy(w, fd)
+	return err
+}
@@ -0,0 +1,318 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func listFiles(t *testing.T, dir string) []string {
+	var files []string
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, file)
+		if rel == "." {
+			return nil
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, _ := os.Readlink(file)
+			rel += " -> " + link
+		} else if info.IsDir() {
+			rel += "/"
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	assert.Nil(t, err)
+	sort.Strings(files)
+	return files
+}
+
+func Test_ArchiveRoundTrip(t *testing.T) {
+	src, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(src)
+	writeTestFiles(t, src, map[string]string{
+		"bin/run":        "#!/bin/sh",
+		"lib/lib.so":     "elf",
+		"docs/README.md": "docs",
+		"build.log":      "log",
+	})
+	assert.Nil(t, os.Chmod(filepath.Join(src, "bin/run"), 0755))
+	assert.Nil(t, os.Symlink("../lib/lib.so", filepath.Join(src, "bin/lib.so")))
+
+	formats := []struct {
+		name    string
+		create  func(w *bytes.Buffer, src string, options ArchiveOptions) error
+		extract func(r *bytes.Buffer, dest string, options ArchiveOptions) error
+	}{
+		{
+			"tar",
+			func(w *bytes.Buffer, src string, o ArchiveOptions) error { return CreateTar(w, src, o) },
+			func(r *bytes.Buffer, dest string, o ArchiveOptions) error { return ExtractTar(r, dest, o) },
+		},
+		{
+			"tar.gz",
+			func(w *bytes.Buffer, src string, o ArchiveOptions) error { return CreateTarGz(w, src, o) },
+			func(r *bytes.Buffer, dest string, o ArchiveOptions) error { return ExtractTarGz(r, dest, o) },
+		},
+		{
+			"zip",
+			func(w *bytes.Buffer, src string, o ArchiveOptions) error { return CreateZip(w, src, o) },
+			func(r *bytes.Buffer, dest string, o ArchiveOptions) error { return ExtractZip(r, dest, o) },
+		},
+	}
+	tests := []struct {
+		create  ArchiveOptions
+		extract ArchiveOptions
+		files   []string
+	}{
+		{
+			ArchiveOptions{Exclude: []string{"*.log"}},
+			ArchiveOptions{},
+			[]string{"bin/", "bin/lib.so -> ../lib/lib.so", "bin/run", "docs/", "docs/README.md",
+				"lib/", "lib/lib.so"},
+		},
+		{
+			ArchiveOptions{Include: []string{"bin", "lib"}},
+			ArchiveOptions{Exclude: []string{"lib.so"}},
+			[]string{"bin/", "bin/run", "docs/", "lib/"},
+		},
+		{
+			ArchiveOptions{Include: []string{"docs"}},
+			ArchiveOptions{StripComponents: 1},
+			[]string{"README.md"},
+		},
+	}
+	for _, format := range formats {
+		for _, test := range tests {
+			var buf bytes.Buffer
+			assert.Nil(t, format.create(&buf, src, test.create), format.name)
+
+			dest, err := ioutil.TempDir("", "ofcourse")
+			assert.Nil(t, err)
+			defer os.RemoveAll(dest)
+			assert.Nil(t, format.extract(&buf, dest, test.extract), format.name)
+			assert.Equal(t, test.files, listFiles(t, dest), format.name)
+		}
+
+		var buf bytes.Buffer
+		assert.Nil(t, format.create(&buf, src, ArchiveOptions{}))
+		dest, err := ioutil.TempDir("", "ofcourse")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dest)
+		assert.Nil(t, format.extract(&buf, dest, ArchiveOptions{}))
+		info, err := os.Stat(filepath.Join(dest, "bin/run"))
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), format.name)
+		content, err := ioutil.ReadFile(filepath.Join(dest, "bin/lib.so"))
+		assert.Nil(t, err)
+		assert.Equal(t, "elf", string(content), format.name)
+	}
+}
+
+func Test_CreateTarFile(t *testing.T) {
+	src, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(src)
+	writeTestFiles(t, src, map[string]string{"a/b.txt": "b"})
+
+	var buf bytes.Buffer
+	assert.Nil(t, CreateTar(&buf, filepath.Join(src, "a/b.txt"), ArchiveOptions{}))
+	header, err := tar.NewReader(&buf).Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "b.txt", header.Name)
+}
+
+func Test_ExtractTarUnsafe(t *testing.T) {
+	tests := []struct {
+		headers []tar.Header
+		err     string
+	}{
+		{
+			[]tar.Header{{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0644}},
+			`archive entry "../evil" is outside of the destination`,
+		},
+		{
+			[]tar.Header{{Name: "a/../../evil", Typeflag: tar.TypeReg, Mode: 0644}},
+			`archive entry "a/../../evil" is outside of the destination`,
+		},
+		{
+			[]tar.Header{{Name: "/etc/evil", Typeflag: tar.TypeReg, Mode: 0644}},
+			`archive entry "/etc/evil" has an absolute path`,
+		},
+		{
+			[]tar.Header{{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc"}},
+			`archive entry "link" links to "/etc" outside of the destination`,
+		},
+		{
+			[]tar.Header{{Name: "a/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"}},
+			`archive entry "a/link" links to "../../etc" outside of the destination`,
+		},
+		{
+			[]tar.Header{
+				{Name: "d/d/d/link", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+				{Name: "x", Typeflag: tar.TypeSymlink, Linkname: "d/d/d/link/../evil"},
+			},
+			`archive entry "x" links to "d/d/d/link/../evil" outside of the destination`,
+		},
+		{
+			[]tar.Header{
+				{Name: "x", Typeflag: tar.TypeSymlink, Linkname: "d/d/d/link/../evil"},
+				{Name: "d/d/d/link", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+			},
+			`archive entry "x" links to "d/d/d/link/../evil" outside of the destination`,
+		},
+		{
+			[]tar.Header{{Name: "hard", Typeflag: tar.TypeLink, Linkname: "../etc/passwd"}},
+			`archive entry "../etc/passwd" is outside of the destination`,
+		},
+		{
+			[]tar.Header{{Name: "fifo", Typeflag: tar.TypeFifo}},
+			`archive entry "fifo" has unsupported type '6'`,
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, header := range test.headers {
+			h := header
+			assert.Nil(t, tw.WriteHeader(&h))
+		}
+		assert.Nil(t, tw.Close())
+
+		dest, err := ioutil.TempDir("", "ofcourse")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dest)
+		err = ExtractTar(&buf, filepath.Join(dest, "out"), ArchiveOptions{})
+		assert.EqualError(t, err, test.err)
+		_, err = os.Lstat(filepath.Join(dest, "evil"))
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Lstat(filepath.Join(dest, "out", "x"))
+		assert.True(t, os.IsNotExist(err))
+	}
+}
+
+func Test_ExtractTarThroughSymlink(t *testing.T) {
+	outside, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(outside)
+	dest, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dest)
+	assert.Nil(t, os.Symlink(outside, filepath.Join(dest, "escape")))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "escape/evil", Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+	_, err = tw.Write([]byte("evil"))
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+
+	err = ExtractTar(&buf, dest, ArchiveOptions{})
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasSuffix(err.Error(), "is outside of the destination"))
+	_, err = os.Lstat(filepath.Join(outside, "evil"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_ExtractTarEscapeThroughLinks(t *testing.T) {
+	tests := []struct {
+		headers []tar.Header
+		// escaped is a path relative to the parent of the destination which
+		// must not exist after extraction.
+		escaped string
+	}{
+		{
+			// A hard link to a symbolic link is a symbolic link in another directory.
+			[]tar.Header{
+				{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0755},
+				{Name: "sub/d", Typeflag: tar.TypeSymlink, Linkname: ".."},
+				{Name: "e", Typeflag: tar.TypeLink, Linkname: "sub/d"},
+			},
+			"out/e",
+		},
+		{
+			// The parent of a file resolves outside of the destination through a
+			// link extracted after the one it passes through.
+			[]tar.Header{
+				{Name: "L", Typeflag: tar.TypeSymlink, Linkname: "sub/d/.."},
+				{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0755},
+				{Name: "sub/d", Typeflag: tar.TypeSymlink, Linkname: ".."},
+				{Name: "L/x/y/f", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+			"x",
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, header := range test.headers {
+			h := header
+			assert.Nil(t, tw.WriteHeader(&h))
+		}
+		assert.Nil(t, tw.Close())
+
+		dir, err := ioutil.TempDir("", "ofcourse")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dir)
+		err = ExtractTar(&buf, filepath.Join(dir, "out"), ArchiveOptions{})
+		assert.NotNil(t, err, test.escaped)
+		_, err = os.Lstat(filepath.Join(dir, test.escaped))
+		assert.True(t, os.IsNotExist(err), test.escaped)
+	}
+}
+
+func Test_ExtractTarReplacesSymlink(t *testing.T) {
+	outside, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(outside)
+	dest, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dest)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(outside, "target"), []byte("safe"), 0644))
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "target"), filepath.Join(dest, "file")))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "file", Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+	_, err = tw.Write([]byte("evil"))
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+
+	assert.Nil(t, ExtractTar(&buf, dest, ArchiveOptions{}))
+	content, err := ioutil.ReadFile(filepath.Join(outside, "target"))
+	assert.Nil(t, err)
+	assert.Equal(t, "safe", string(content))
+	content, err = ioutil.ReadFile(filepath.Join(dest, "file"))
+	assert.Nil(t, err)
+	assert.Equal(t, "evil", string(content))
+}
@@ -0,0 +1,201 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// VersionFile is the name of the file holding the version in the output
+	// directory when Config.ResultFiles is enabled.
+	VersionFile = "version.json"
+	// MetadataFile is the name of the file holding the metadata in the output
+	// directory when Config.ResultFiles is enabled.
+	MetadataFile = "metadata.json"
+)
+
+// AtomicFile is a file that is written to a temporary file in the same directory,
+// then renamed to its final path on Commit, so that a partially written file is
+// never seen at that path.
+type AtomicFile struct {
+	*os.File
+	path string
+	perm os.FileMode
+	done bool
+}
+
+// CreateAtomic returns an AtomicFile which will be renamed to path with the
+// permissions perm when committed.
+func CreateAtomic(path string, perm os.FileMode) (*AtomicFile, error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	file, err := ioutil.TempFile(dir, "."+base+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &AtomicFile{File: file, path: path, perm: perm}, nil
+}
+
+// Commit flushes the file to disk and renames it to its final path. If this fails,
+// the temporary file is removed.
+func (a *AtomicFile) Commit() error {
+	if a.done {
+		return fmt.Errorf("%s: file already closed", a.path)
+	}
+	a.done = true
+	err := a.File.Sync()
+	if err == nil {
+		err = a.File.Chmod(a.perm)
+	}
+	if closeErr := a.File.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(a.File.Name(), a.path)
+	}
+	if err != nil {
+		os.Remove(a.File.Name())
+	}
+	return err
+}
+
+// Abort closes and removes the temporary file, leaving any existing file at the
+// final path untouched. It does nothing if the file has been committed, so it
+// may be deferred.
+func (a *AtomicFile) Abort() error {
+	if a.done {
+		return nil
+	}
+	a.done = true
+	a.File.Close()
+	return os.Remove(a.File.Name())
+}
+
+// WriteFileAtomic is like ioutil.WriteFile, but the data is written to a temporary
+// file that is renamed to path, so path holds either its previous contents or all
+// of data, even if writing fails partway through.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return writeAtomic(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteJSONAtomic atomically writes the JSON encoding of v to path.
+func WriteJSONAtomic(path string, v interface{}, perm os.FileMode) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, append(data, '\n'), perm)
+}
+
+// CopyAtomic atomically writes everything read from r to path.
+func CopyAtomic(path string, r io.Reader, perm os.FileMode) error {
+	return writeAtomic(path, perm, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
+func writeAtomic(path string, perm os.FileMode, write func(io.Writer) error) error {
+	file, err := CreateAtomic(path, perm)
+	if err != nil {
+		return err
+	}
+	defer file.Abort()
+	err = write(file)
+	if err != nil {
+		return err
+	}
+	return file.Commit()
+}
+
+// WriteResultFiles writes version to VersionFile and metadata to MetadataFile
+// in dir, and the value of each version key to a file named after the key, so
+// that tasks may read them without parsing JSON. This is done by the `in`
+// dispatcher when Config.ResultFiles is enabled. Nothing is written if any of
+// the files already exists, so that files written by In are never replaced.
+func WriteResultFiles(dir string, version Version, metadata Metadata) error {
+	names := []string{VersionFile, MetadataFile}
+	for key := range version {
+		if !validResultFileName(key) {
+			return fmt.Errorf("version key %q cannot be used as a file name", key)
+		}
+		names = append(names, key)
+	}
+	for _, name := range names {
+		_, err := os.Lstat(filepath.Join(dir, name))
+		if err == nil {
+			return fmt.Errorf("result file %q already exists in the output directory", name)
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if version == nil {
+		version = Version{}
+	}
+	if metadata == nil {
+		metadata = Metadata{}
+	}
+	err := WriteJSONAtomic(filepath.Join(dir, VersionFile), version, 0644)
+	if err != nil {
+		return err
+	}
+	err = WriteJSONAtomic(filepath.Join(dir, MetadataFile), metadata, 0644)
+	if err != nil {
+		return err
+	}
+	for key, value := range version {
+		err = WriteFileAtomic(filepath.Join(dir, key), []byte(value), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeResultFiles removes the files written by WriteResultFiles.
+func removeResultFiles(dir string, version Version) {
+	os.Remove(filepath.Join(dir, VersionFile))
+	os.Remove(filepath.Join(dir, MetadataFile))
+	for key := range version {
+		os.Remove(filepath.Join(dir, key))
+	}
+}
+
+func validResultFileName(name string) bool {
+	switch name {
+	case "", ".", "..", VersionFile, MetadataFile:
+		return false
+	}
+	return !strings.ContainsAny(name, `/\`)
+}
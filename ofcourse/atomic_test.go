@@ -0,0 +1,139 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type resultFilesResource struct {
+	emptyResource
+	version Version
+}
+
+func (r *resultFilesResource) Config() Config {
+	return Config{ResultFiles: true}
+}
+
+func (r *resultFilesResource) In(outDir string, source Source, params Params,
+	version Version, env Environment, logger *Logger) (Version, Metadata, error) {
+	return r.version, Metadata{{Name: "size", Value: "1 KiB"}}, nil
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func Test_WriteFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "artifact")
+	assert.Nil(t, WriteFileAtomic(path, []byte("first"), 0600))
+	assert.Nil(t, WriteFileAtomic(path, []byte("second"), 0600))
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", string(content))
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	err = CopyAtomic(path, failingReader{}, 0644)
+	assert.EqualError(t, err, "connection reset")
+	content, err = ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", string(content))
+
+	file, err := CreateAtomic(filepath.Join(dir, "aborted"), 0644)
+	assert.Nil(t, err)
+	_, err = file.WriteString("partial")
+	assert.Nil(t, err)
+	assert.Nil(t, file.Abort())
+	assert.Nil(t, file.Abort())
+
+	assert.Nil(t, WriteJSONAtomic(filepath.Join(dir, "data.json"), map[string]int{"a": 1}, 0644))
+	content, err = ioutil.ReadFile(filepath.Join(dir, "data.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"a\": 1\n}\n", string(content))
+
+	assert.Equal(t, []string{"artifact", "data.json"}, listFiles(t, dir))
+}
+
+func Test_inResultFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	resource := &resultFilesResource{version: Version{"ref": "abc123", "tag": "v1.0.0"}}
+	_, err = in(resource, dir, []byte(`{"source": {}, "version": {"ref": "abc123"}}`))
+	assert.Nil(t, err)
+
+	expected := map[string]string{
+		"metadata.json": "[\n  {\n    \"name\": \"size\",\n    \"value\": \"1 KiB\"\n  }\n]\n",
+		"ref":           "abc123",
+		"tag":           "v1.0.0",
+		"version.json":  "{\n  \"ref\": \"abc123\",\n  \"tag\": \"v1.0.0\"\n}\n",
+	}
+	assert.Equal(t, []string{"metadata.json", "ref", "tag", "version.json"}, listFiles(t, dir))
+	for name, value := range expected {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(content))
+	}
+
+	resource = &resultFilesResource{version: Version{"ref/name": "main"}}
+	_, err = in(resource, dir, []byte(`{"source": {}, "version": {}}`))
+	assert.EqualError(t, err, `version key "ref/name" cannot be used as a file name`)
+
+	for _, key := range []string{"", "..", VersionFile, MetadataFile, `a\b`} {
+		err = WriteResultFiles(dir, Version{key: "x"}, nil)
+		assert.True(t, strings.HasPrefix(err.Error(), "version key"), key)
+	}
+}
+
+func Test_WriteResultFilesExisting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "tag"), []byte("written by in"), 0644))
+	err = WriteResultFiles(dir, Version{"ref": "abc123", "tag": "v1.0.0"}, nil)
+	assert.EqualError(t, err, `result file "tag" already exists in the output directory`)
+	assert.Equal(t, []string{"tag"}, listFiles(t, dir))
+	content, err := ioutil.ReadFile(filepath.Join(dir, "tag"))
+	assert.Nil(t, err)
+	assert.Equal(t, "written by in", string(content))
+
+	err = WriteResultFiles(dir, Version{"ref": "abc123"}, nil)
+	assert.Nil(t, err)
+	err = WriteResultFiles(dir, Version{"ref": "abc123"}, nil)
+	assert.EqualError(t, err, `result file "version.json" already exists in the output directory`)
+}
@@ -126,7 +126,7 @@ func makefile() (*asset, error) {
 	return a, nil
 }
 
-var _readmeMd = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x55\x4d\x6f\x1b\x37\x14\xbc\xf3\x57\x0c\xa0\x9b\x10\x6d\xaa\xb6\x41\x81\x05\x7a\x49\xec\x16\x3d\x34\x31\x94\xb4\x3d\x9a\xd4\xee\x13\xc9\x8a\x4b\x6e\xf9\x21\xc5\x08\xf2\xdf\x8b\xc7\xd5\xc6\x56\x5c\xb9\xee\xa1\x27\x49\xe4\xcc\x9b\x79\xc3\x47\x6a\x81\x4f\x9f\xd0\x6c\x28\x85\x12\x3b\xc2\xe7\xcf\x42\xfc\x11\x6d\x26\x28\xf4\x94\xba\x68\xc7\x6c\x83\x47\xd8\x21\x1b\x42\x9c\x71\x86\x22\x35\x42\x2c\x16\x78\x3f\x2d\xbc\x09\x7e\x67\x75\x89\x8a\xe1\x42\x2c\x21\x95\x6c\xb1\xdc\xd0\x5f\xc5\x46\xea\x9b\x25\x3e\x18\x9b\x60\x13\x14\xe2\x69\x11\x89\x72\xb6\x5e\x37\x15\xbf\x65\xfc\xbb\x2a\xa7\xdc\x43\xbc\x47\x38\xad\x9e\x13\xba\x33\x02\xae\x68\xa7\x8a\xcb\x90\x39\x16\x92\x4f\xf2\x71\xb4\xd9\xd4\x0e\x27\xca\x41\xb9\x32\xb5\xb3\xc0\xf5\x47\x35\x8c\x8e\x84\x90\x52\xde\xa9\xc1\x89\xb9\xe7\xdb\x7c\x37\x52\x6a\xc5\x0a\x5e\x0d\xd4\x3e\xca\x0d\x60\x40\x8b\x48\xda\xa6\x1c\xef\x56\x76\x50\x9a\x04\x30\x41\x5a\x01\x00\x91\xc6\x90\x6c\x0e\xf1\x6e\x2a\x70\x15\xba\x3d\xc5\xcd\x89\x52\xe3\x9f\xf5\x9e\x21\xf5\x78\xbd\x33\xd4\xed\x6f\xe9\x40\x2c\xf0\x6a\xf8\x4a\xdd\x05\x7d\xeb\xe8\x40\xae\x45\x4f\xdb\xa2\x85\xf8\x33\x6c\x1f\xe8\xf4\x61\x65\xb3\x00\x46\xa7\x3c\x33\x56\xd0\x94\xff\x49\x06\xc8\xd1\x6a\x4d\xb1\x05\xa7\x5d\xa1\x63\xb9\x00\x1d\x55\x54\x43\x9a\x1c\x00\x07\x8a\xc9\x06\x7f\x3b\xaa\x6c\x1e\xe1\x5f\x9e\x76\x39\xfc\x3a\x5d\xaf\xc9\xa8\x83\x0d\x71\x3a\x1b\x59\xdb\x93\x2d\xde\xf0\x27\x76\x21\x22\x85\x81\xb2\xb1\x5e\x5f\x9c\xdb\xa3\x51\x99\xe7\xa0\x72\xa9\xbf\x1f\xdd\x05\xa4\xf5\xb2\xc5\x4f\x94\x3b\xf3\x1f\x0a\xed\x18\x7f\x56\x68\x81\x1b\xee\x91\x32\xc5\xf4\xdc\xd9\x1f\x67\xc6\xf3\xa7\xff\x21\xa5\xda\x0f\x25\xcb\x16\x37\x25\xdf\xbb\xaf\xdf\x8e\x6c\xed\x5f\xfb\xd8\x12\xe3\xc7\x13\xfb\xf8\x3f\xb6\xf3\xac\xbb\xf9\xa5\xc2\xe5\xdb\x89\x2b\x1e\xde\x30\x0e\xe4\xf3\x14\xc1\x4d\xa4\x6a\x21\xd9\x4c\xd5\xab\x0e\x4e\x79\xcd\xb5\x97\xb3\xb7\x25\x56\xf3\xd4\x61\xdd\xac\xd7\xcd\x47\x84\x08\x63\xb5\xa1\xc8\xc0\x19\xd7\x88\x25\xfa\x7a\x1f\x2f\xd3\x7f\x68\xbe\x79\xf5\x14\x7f\x50\x7b\xba\xc8\xfe\xbe\x59\x73\xfe\x3f\xbf\xfd\xed\x0b\x2e\x53\xca\xcc\xac\xcd\x6c\x8a\xf7\x7c\x24\xfc\xd2\xf2\x46\x12\xe2\x83\x21\xfc\xaa\xf6\xb4\xb3\x8e\x60\x7d\xe7\x4a\x4f\x9c\xbb\xe4\x7d\x89\xac\xa2\xa6\xfc\x02\xca\xf7\x13\x03\x2a\x12\x94\x4b\x01\xb1\x78\x58\x9f\x6c\x4f\xb5\xde\xf4\xd0\x60\x5b\xac\x63\xb9\x4d\xf1\xf7\x32\x53\xe0\xfc\x73\x17\x9c\x0b\x47\xf6\xd0\x85\x61\x50\xbe\x6f\xeb\x3b\x98\x8c\xa8\x86\x19\x3d\x5f\xcd\x05\x5e\x73\x2d\xc6\xb2\xfa\x58\xb6\xce\x26\x33\xdb\x9f\xde\xbe\x0b\xf6\x27\xd7\xa9\xde\xdf\xed\x13\x45\xe6\xc3\xe0\x5a\x0d\xae\x55\x67\x4e\xff\x43\x89\x44\x56\x7b\x3a\x9f\x1f\xf9\xfb\xf5\xe6\xfd\x2f\xef\xde\x4a\xa8\xa8\x0b\x8f\xc8\x0b\x1c\x8d\xed\x0c\x8e\xd6\x39\x64\x55\x35\x5e\x86\x88\xb1\x24\xf3\x48\xa0\x86\x20\x78\x55\xdb\x03\xf9\xf9\xd0\x9a\xb3\x00\x4e\x12\x3f\xae\x9b\x6f\x9b\xef\xa6\xa5\x93\xe7\xaf\xb6\x38\xa4\xbf\x03\x00\x00\xff\xff\xd1\xf7\x8a\x3f\x5b\x07\x00\x00")
+var _readmeMd = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x55\x4d\x6f\xe3\x36\x10\xbd\xf3\x57\x3c\xc0\x37\x23\xd1\xd6\x6d\x17\x05\x04\xf4\xb2\x9b\x6c\xd1\x02\x4d\x02\x67\xdb\x1e\x43\x5a\x1a\x8b\xac\x29\x52\xe5\x87\xbd\xc1\x62\xff\x7b\x31\x94\x94\xc4\x9b\x3a\x4d\x81\x22\x87\x44\xc3\xf7\xe6\xcd\x3c\xce\x30\x0b\x7c\xfe\x8c\x6a\x4d\xd1\xe7\xd0\x10\xbe\x7c\x11\xe2\x8f\x60\x12\x41\xa1\xa5\xd8\x04\x33\x24\xe3\x1d\xfc\x16\x49\x13\xc2\x8c\xd3\x14\xa8\x12\x62\xb1\xc0\xed\x18\x78\xef\xdd\xd6\x74\x39\x28\x86\x0b\xb1\x84\x54\xb2\xc6\x72\x4d\x7f\x65\x13\xa8\xad\x96\xf8\xa8\x4d\x84\x89\x50\x08\x53\x10\x91\x52\x32\xae\xab\x0a\x7e\xc3\xf8\xeb\x22\xa7\xec\x53\xbc\x83\x9f\xa2\xc7\x84\xe6\x88\x80\x0b\xda\xaa\x6c\x13\x64\x0a\x99\xe4\x8b\x7c\x1c\x4c\xd2\xa5\xc3\x91\xb2\x57\x36\x8f\xed\x2c\x70\xf9\x49\xf5\x83\x25\x21\xa4\x94\xf7\xaa\xb7\x62\xee\xf9\x2e\xdd\x0f\x14\x6b\x71\x0e\xa7\x7a\xaa\x9f\xf9\x06\x30\xa0\x46\xa0\xce\xc4\x14\xee\xcf\x4d\xaf\x3a\x12\xc0\x48\xaf\x05\x00\x04\x1a\x7c\x34\xc9\x87\xfb\x31\xc1\x85\x6f\x76\x14\xd6\x13\xa5\xd8\x3f\xeb\xbd\x42\xea\x79\xbc\xd1\xd4\xec\xee\x68\x4f\x2c\xf0\xb6\xff\x4a\xdd\xfa\xee\xce\xd2\x9e\x6c\x8d\x96\x36\xb9\x13\xe2\x4f\xbf\x79\xa2\xd3\xfa\x73\x93\x04\x30\x58\xe5\xb8\xde\x73\x74\x94\xfe\x49\x06\x48\xc1\x74\x1d\x85\x1a\xec\x76\x81\x0e\xf9\x04\x74\x50\x41\xf5\x91\xf3\xf1\xcf\x9e\x42\x34\xde\xdd\x0d\x2a\xe9\x67\xf8\x37\xd3\x29\x9b\xcf\xd7\x81\x77\xa4\xd5\xde\xf8\xc0\x1f\x0b\xc8\xd2\x9e\xac\xf1\x9e\x7f\x63\xeb\x03\xa2\xef\x29\x69\xe3\xba\x93\x73\x7b\xd0\x2a\xf1\x1c\x14\x2e\xb5\x8f\xa3\xbb\x80\x34\x4e\xd6\xf8\x40\xa9\xd1\xff\x21\xd1\x96\xf1\x47\x89\x16\xf8\x60\x2c\xc5\x32\xc6\x53\x07\xb2\xc6\x47\x4d\x0f\xd8\x29\x7a\x06\x15\xf1\xcb\xed\xf5\xd5\xcc\xbb\x61\x6f\x28\x51\x88\xaf\xdd\x99\x61\x66\xbc\x7e\x6b\x9e\x52\x58\x54\xfa\x9c\x64\x8d\x9b\x9c\x1e\xbb\x2e\x7f\x1d\xd8\x9b\x7f\xed\x7f\x43\xbc\x42\xc3\xc4\x3e\x3c\xb5\xe1\x7f\x6e\xe7\x55\x3b\xfd\x90\xe1\xf4\x56\xe3\x82\x87\xde\x0f\x3d\xb9\xc4\xdf\x0b\xdc\x04\x2a\x8e\x46\x93\xc6\x7b\xeb\xbc\x55\xae\x63\xe7\x96\x73\x6d\x4b\x9c\xcf\xf7\x86\x55\xb5\x5a\x55\x9f\xe0\x03\xb4\xe9\x34\x05\x06\xce\xb8\x4a\x2c\xd1\x96\x3d\x3e\x4d\xff\xa1\xfa\xe6\xed\x4b\xfc\x5e\xed\xe8\x24\xfb\xfb\x6a\xc5\x0f\xf0\x4f\x57\xbf\x3d\xe0\x12\xc5\xc4\xcc\xd2\xcc\x3a\x3b\xc7\x57\xc2\x2f\x34\x1f\x44\x21\x78\xf6\x7e\x55\x3b\xda\x1a\x4b\x30\xae\xb1\xb9\x25\x1e\x23\xc9\xe7\x12\x49\x85\x8e\xd2\x19\x94\x6b\xc1\x91\x08\x15\x08\xca\x46\x8f\x90\x1d\x8c\x8b\xa6\xa5\xf2\xe2\x8f\x0f\x14\x36\xd9\x58\x96\x5b\x67\xf7\x28\x33\x1a\xce\x9f\x5b\x6f\xad\x3f\x70\x0d\x8d\xef\x7b\xe5\xda\xba\xbc\x9f\x51\x8b\x52\x30\x4b\xcc\x2b\xbd\xc0\x3b\xce\xc5\x58\x56\x1f\xf2\xc6\x9a\xa8\xe7\xf2\xc7\x37\xf3\x44\xf9\x63\xd5\xb1\xec\xfd\xe6\x85\x24\xf3\x65\x70\xae\x0a\x97\xaa\xd1\xd3\xff\xaf\x48\x22\xa9\x1d\x1d\xcf\x8f\xfc\xfd\x72\x7d\xfb\xf3\xf5\x95\x84\x0a\x5d\xe6\x11\x39\xc3\x41\x9b\x46\xe3\x60\xac\x45\x52\xa5\xd0\x37\x3e\x60\xc8\x51\x3f\x13\x28\x26\x08\x8e\x76\x66\x4f\x6e\xbe\xb4\xea\xc8\x80\x49\xe2\xc7\x55\xf5\x6d\xf5\xdd\xe8\xc9\x54\xf3\x57\x47\x52\x4a\xf1\xf7\x00\xb1\x18\xed\x87\x93\x07\x00\x00")
 
 func readmeMdBytes() ([]byte, error) {
 	return bindataRead(
@@ -141,7 +141,7 @@ func readmeMd() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "README.md", size: 1883, mode: os.FileMode(420), modTime: time.Unix(1564490512, 0)}
+	info := bindataFileInfo{name: "README.md", size: 1939, mode: os.FileMode(420), modTime: time.Unix(1792351677, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -246,7 +246,7 @@ func pipelineYml() (*asset, error) {
 	return a, nil
 }
 
-var _resourceResourceGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\x5f\x8f\xdb\xc6\x11\x7f\x16\x3f\xc5\x58\x40\x1a\x29\x65\xa9\xa6\x08\xfa\x70\xc5\x3d\x34\xb6\x83\x5c\xdb\xd8\x86\xff\xc4\x0f\x86\x51\x8d\xc8\xa1\xb8\x16\xb9\xcb\xec\x2e\x25\x0b\xc6\x7d\xf7\x62\x66\x77\x49\xea\xce\xae\x63\x23\x4f\x27\xe9\x66\x67\x66\xe7\xcf\xef\x37\xb3\x9b\x0d\x3c\xc3\xf2\x80\x7b\x02\x4b\xce\x0c\xb6\x24\x50\x0e\x50\x83\xea\xfa\x96\x3a\xd2\x1e\xbd\x32\x1a\x4c\x0d\x08\x0f\x8d\x2e\xcd\x60\xdd\x24\x5c\x64\xfd\x9d\xe3\x59\xa6\xba\xde\x58\x0f\xab\x6c\xb1\x24\x5d\x9a\x4a\xe9\xfd\xe6\x9d\x33\x7a\xc9\x3f\x58\x6b\xac\xe3\x4f\x75\xe7\x97\xd9\xc2\x94\xb0\xdc\x2b\xdf\x0c\xbb\xa2\x34\xdd\xa6\x6c\xcd\x50\xed\x8c\x73\x1b\x53\x07\x4b\xe3\x07\x3e\xa3\xcc\x46\x99\xc1\xab\x96\xbf\x38\x6f\x4b\xa3\x8f\xcb\x6c\x9d\x65\x9b\x0d\xbc\x6c\x94\xbb\xb8\x83\xd1\xed\x19\x10\xdc\x81\x5a\xf2\x46\x43\x6d\x2c\xec\xc9\x7b\xa5\xf7\xe0\x3c\x5a\x4f\x55\x01\xaf\x1b\xf4\xa0\x3c\x54\x86\xdc\x55\xb6\xd9\xb0\xa6\x9f\x8c\x85\xed\xc3\x86\xca\xc3\x36\xe7\xff\x29\x5d\x5a\x89\x84\x03\xe5\x1d\x1c\xc9\x3a\x8e\x08\x61\xd9\x80\x57\x1d\x89\x8c\x83\x12\xdb\x96\xaa\x3c\xe8\x66\x23\x27\xe5\x1b\xd8\x7e\x58\x96\x66\xd0\x7e\x79\x05\xcb\xef\x97\xb7\xdb\x02\x5e\x36\xc4\x56\x34\xbd\xf7\xa3\xae\x93\x19\xda\x0a\x76\x74\x21\xfe\xb7\xe5\xed\x36\x07\xd4\x15\x38\x03\x46\x17\x73\xf7\x6e\x74\xf0\xed\x64\x95\x27\x07\x08\xb5\x6a\x09\x4a\xa3\x3d\x2a\xcd\xc6\x7d\x43\xd0\xa2\x27\x37\x19\xf1\x46\xfc\x37\x83\xef\x07\x0f\x95\xb2\x54\x7a\x63\xcf\x17\x7a\x9f\x0e\x3e\x28\x6e\x8d\x39\x38\x50\x5a\x14\x8d\xb2\x50\x5a\x42\x4f\x15\xec\xce\xe2\x83\x04\x95\x25\x92\x0d\x71\x83\x5d\xb6\x84\x95\x0b\xd1\xf3\x06\x10\x3a\xec\x73\xb6\x62\xc9\x0f\x56\x3c\x54\x1e\x76\x58\x1e\xd8\xad\xb1\xae\x92\x2f\x37\x1a\x4a\x74\xc4\xaa\x6d\x2c\xc8\x33\xdf\xae\x1e\xc4\x0a\xee\xcc\xe0\xe1\xd4\xa8\xb2\x89\xbe\x29\xa3\x5d\xf0\x88\x8d\xcb\x35\xc0\x52\x4d\x16\xbc\xc9\xe1\x6c\x06\x70\x8d\xc4\x78\x2c\x6b\x36\x23\x07\xbc\x11\x67\xc1\x52\x67\x3c\x71\xcd\xe8\x2a\xc4\x15\x5a\xc3\x49\x3d\xe7\x6c\xaa\xa5\xa8\x36\x2a\x92\x33\x51\x60\x76\x24\x69\x29\x44\x79\xb8\x71\x49\xea\x48\xd2\x54\x77\x63\x1f\xb2\x1b\x14\xf6\x2d\x72\xe1\x7a\xa9\xe2\xa1\xf5\xf1\xf2\x58\x7b\xb2\x1c\x36\xab\xe8\x18\xe3\x56\x5b\xd3\x81\x33\x1d\xc1\x6a\x70\x03\x7b\xb0\x0e\x96\xd8\x36\x84\x16\xc8\x25\x5e\x6a\x3f\x58\xaa\xc0\x37\xd6\x0c\xfb\x06\xb6\xe1\x7f\xdb\x94\xd8\x5e\xf5\xd4\x2a\x4d\xdf\xce\x5a\xa7\xa2\x5a\x69\xc5\x11\x2d\xc6\x40\x8e\x37\x60\x33\x4a\x5f\xde\x21\x05\x90\xbd\xca\xff\xef\x8d\x52\xbe\xd9\xf4\x85\xb3\x29\xef\xd2\xc4\x63\xc7\x4e\x2e\x19\x72\xa0\x8d\x87\x8a\xb0\x0d\x7d\x85\x97\x0a\xfe\xc1\x61\x71\x9c\xdc\x73\x2c\xbd\x31\x25\x2e\x64\x49\x4a\xd3\x25\x43\xff\x26\xea\x39\x08\x9d\xd2\x55\x0e\x9e\xcd\x8e\x90\xa1\x81\xde\x23\x97\xc9\x03\xf8\x91\x4e\x68\x49\xc0\xaf\x3d\xe1\xd9\xcd\xca\x17\x61\xd0\xea\xb7\x61\x2a\x7d\x86\x84\x23\x71\x8f\x30\x74\xb0\x11\xbe\xe6\x09\xcf\x41\x7d\xd4\x29\x77\x29\xe0\xa6\x96\xa2\x6c\xf0\x48\xd0\x71\x71\xbb\xa1\x6c\xc6\x0b\x3b\xf0\x8c\x4b\x6c\x5a\x94\x11\x07\x97\x7e\x1b\x48\x7b\x76\xb0\xb4\xc6\x39\x39\xc6\x56\x52\x0e\x5d\x00\x04\xd5\xb6\xc0\xf9\x41\x68\x8d\x67\xcf\x5b\x83\x15\x3b\x27\x8d\x8c\x1e\x77\xe8\x38\xdf\x0f\x9f\xbd\x2a\xb2\xec\x88\x96\x81\x7a\xb3\x81\xc7\xd6\xfe\x1a\x6f\xd2\x11\xea\x09\xe7\x3a\xec\x39\x36\x1d\xb6\xb5\xb1\x1d\x55\xd9\x62\x26\x7a\x0d\x01\xd1\x8b\x27\x74\x5a\x6d\x0f\x74\x86\x88\x5f\x92\xae\xda\x0c\xba\xe2\x30\xcf\x74\x6d\xd7\xc9\xdc\x33\xb4\xd8\x45\x63\x3d\x7f\x26\x4f\xd6\xc9\xad\x2f\x8d\x05\xc1\x4b\x53\x9d\x72\x8e\xd3\xb0\x8c\xaa\xff\xdb\xa3\x6f\x96\x93\x9e\xed\x3a\xf2\xc2\xf3\x91\x12\x52\xe7\x3b\x09\x45\xe2\x95\x62\x12\xd0\x9e\x6c\x8d\x5c\x8d\xfe\xdc\xd3\x74\xd2\x79\x3b\x94\xfe\xc3\xad\xe8\x13\x62\xf8\xbc\xb2\x20\xd6\x91\x6f\x4c\xc5\xad\x68\x2d\xb9\xde\xe8\x4a\xb0\x39\x74\xc0\xc6\xf4\x7e\x93\x52\xbe\x91\x44\x43\x69\xba\x0e\x75\x55\x8c\xcd\x30\x12\x0b\x9c\x1a\xd2\x33\x06\x96\x9e\x88\xbd\x15\x4c\x8a\x06\x97\x83\xb1\x41\x96\x6d\x6c\xeb\x36\x16\xe4\x5f\x92\xdc\x36\x19\x61\xdd\x76\xd0\x45\x56\x0f\xba\x84\x95\x85\xef\x92\xf7\xeb\xe0\xfe\x2a\x2a\x36\x65\xf1\x22\x62\xca\x58\xec\x65\x11\x4b\x20\x07\xd2\x47\xfe\xfe\x58\x1f\x95\x35\x9a\x83\x92\x67\x8b\xd6\xec\xf7\x64\xe1\x3b\x53\x16\xff\x91\x8f\x6b\x58\xbd\x79\x7b\x71\x8c\xb3\xb9\x86\x0f\x52\x0e\xcf\xa5\xaf\xa8\x82\x6d\xb4\xe0\x46\xa0\xdd\x11\x60\xdb\x72\x25\xcf\x88\xc6\x81\x53\xba\xa4\x10\x7c\x4d\xb0\x57\x47\xd2\x09\xd5\x92\x8e\xad\xa8\x46\xbb\x1f\xd8\x29\x69\xba\xf1\x5f\x7c\x79\xad\x5a\xee\x7d\xd2\xb1\xad\xe5\x70\xad\xac\xf3\x80\x47\x54\x2d\xee\xda\xd1\x60\xc1\x9c\x24\x9d\xca\xc4\xe4\x02\x38\x8b\x7e\xe9\xb8\x1d\x05\xf0\x60\x5f\x66\x7c\x1b\xf4\xe6\x50\x51\x4f\x21\xf7\xb1\x11\xa5\xc0\x4c\x3d\x25\x6f\x47\x82\xed\xa9\xac\xa8\x2a\x44\x39\x93\x72\x44\x8e\x1c\x10\xf6\xca\x4f\x47\x4e\x91\x87\xc4\x75\x84\x56\x39\xe9\x77\xce\x2e\xd7\xc5\xa7\x03\x24\x9a\xa7\x48\xa4\x00\x31\xcd\x91\x25\x8c\xe8\x13\xd4\x73\x1b\x77\x78\x20\x70\xa4\x1d\x09\xdf\x4f\x28\x15\x19\xd8\x88\x94\x60\x19\x63\x12\x6b\x3f\x28\x5d\x09\xf8\x28\x4d\x68\x53\x48\x94\xde\x17\x59\xb6\x10\x84\x80\xab\x6b\x9e\x88\xb2\x85\xaa\xc7\x88\x3d\xb8\xe6\x9c\x70\x49\x2c\x4c\x5b\x3d\x64\xb1\x1c\xcc\x81\x45\xa3\xc8\x9b\x08\x2f\x6f\xb3\x05\x1f\x7c\x60\x0e\x22\xbd\x88\x51\x90\x8c\x4e\xf0\x94\x2d\x16\xb7\x2c\x28\xc5\xc6\x5a\xe2\xcc\x58\xfc\xd3\x1b\xb5\x4a\x26\xd6\x41\x17\x8b\xcc\x1c\xb8\x50\x49\xd6\x46\x5d\xc1\xf7\x49\xd3\x8d\x37\xb8\x52\xf0\x67\xf8\x7e\x9d\x2d\x6e\x33\xb9\xfc\xcd\xac\x4f\x39\x6b\xe9\x7a\x61\xb0\x46\xbb\x53\xde\xa2\x3d\x83\x23\x49\x98\xf3\x96\x73\x7f\xa0\x73\x60\xad\xf8\xfd\x88\xed\xc0\x84\xb5\x48\x50\xd0\xf3\x5c\x59\x0e\xed\x14\x4f\xa6\x78\xa7\x1c\x0f\x75\x35\xbc\x1b\x38\xff\x9a\x58\x91\xe8\x11\x05\x45\xb6\xd0\x74\x4a\x70\x7d\x75\x3d\xeb\xdc\x69\xd4\x94\xbf\xec\xfc\xd8\x5d\x57\xd7\x30\xef\xd6\x0f\x93\x0e\x16\x8b\xa1\x49\xd2\x39\x07\x29\x0b\xf0\x78\xa3\x3f\x8f\x8d\xdc\x49\xbf\x1f\x18\x95\xfe\x2c\x2a\xce\x37\x93\x77\x66\x17\xb0\x71\xbb\x27\xbf\x4d\xfd\x36\xed\x2b\x1f\x01\xbb\x1b\xbd\x0a\x83\xd9\xa3\x71\xa6\x09\x39\xc8\xe1\x3e\x02\x0a\xc3\x38\xfe\x45\x68\xc9\x7d\x14\x13\xb3\xc5\x47\x40\x11\x3e\x86\x89\x73\x44\x34\x65\xf1\x0b\x79\x64\x92\xbe\x03\x8f\x8f\xa8\x33\x81\xcb\xf7\x7b\x6e\xa2\x91\x9b\x5c\x42\x49\xcd\xb3\x07\x0c\xdc\xa2\x9d\x2f\x9e\x59\xa5\x7d\xcd\x4c\x80\xfa\xec\x9b\xb0\x10\x60\x5a\x17\x44\xa5\x37\xbc\xa9\xe8\x0a\x6d\x15\xc7\xd2\x1c\xd0\x8d\x23\x04\x27\x66\xe8\x65\x08\x85\x7f\xbd\x78\xfa\x24\x8d\xae\xf4\xbe\xa7\x32\x6e\x00\xb3\xb1\x3d\xe2\x7d\xf1\x98\xbd\xae\x57\xcb\x94\x25\x1e\xa6\xf8\xa7\xe5\x7a\x14\x79\x8d\x56\xcf\x25\xe0\x84\x32\x51\xcd\x44\x6e\x74\x6d\x2e\x95\x28\xcd\xf3\x80\xac\xa2\xd8\x42\x47\xce\xe1\x9e\x66\x27\x1e\xd1\x6e\xd8\x5f\x68\xad\xf8\x97\x99\xa4\x5c\xfa\xb5\xcc\xe8\x17\x1c\x31\xc2\x1f\xc8\x9e\x22\x0b\x4c\xe4\x91\xbb\xd3\x7a\x2e\x3a\x5c\x28\xd2\x30\x1a\x73\x35\xc9\x7e\x5c\xa2\x0e\x53\xb0\xf2\x45\xb6\x08\x27\x9f\xa1\x6f\xb8\x93\x38\x23\x2f\x7a\x49\xc9\x6a\xf9\x8d\xdb\x44\xd3\xcb\x1c\xee\x94\xdd\x3a\x5b\xec\xce\x9e\x27\xb9\x88\x57\xbc\x36\x17\xbf\xa0\x75\x0d\xb6\xab\x78\x6c\x9d\x7d\x04\xad\xe6\x60\x35\x21\xd6\xed\xbd\xf8\xc4\x5a\xbb\x82\x6f\xdc\x32\x8f\x55\xbe\x12\x9b\x6b\x0e\x11\x6b\xbd\x86\xb0\x63\x17\x12\xac\x9f\x54\x4b\xab\xe9\x3a\x39\x44\x07\xff\xfa\xf7\x1f\x7e\xf8\x22\x4f\x24\x76\xa9\xbc\x2f\x80\x6b\x02\x44\x8d\x1d\x6d\x04\xb6\xa0\x47\x65\x9d\xd0\x4d\xa5\x5c\xdf\xe2\x39\x25\x65\xea\xf4\x57\x37\x21\x21\x8c\x75\x1d\x9e\x99\x83\x6d\x1a\x23\xa8\xeb\xfd\x19\x54\x2d\xcc\xa4\x89\x2a\x21\xd4\x2e\x99\x0f\x38\x98\xbc\x61\xb7\x05\xf2\x9f\x60\x47\x57\x00\x4b\x5c\xe6\xfc\xf5\x57\xf6\xe4\x0a\x96\x3b\xf9\x7a\x9b\xdf\x15\x2b\x2f\xc5\xaa\x51\x2c\xde\xf6\x67\xb2\x94\x5f\x8e\x1c\x3d\x3a\x37\xdb\xc7\x64\x91\xe3\x5b\xcd\x86\x14\x0d\x9d\x71\x3e\x8c\x19\x32\xd2\x33\xfd\xba\xc0\xbf\xa9\x73\xe3\x3a\x18\x4a\x59\xc4\x79\x47\xd3\xe3\xae\x9f\x83\x2a\xa8\x18\xc9\xff\xee\x5c\x34\xa3\x7c\xd4\x55\x50\x3a\x1b\x83\xd2\x66\x3e\xe8\xb2\x41\xbd\xa7\xaa\x80\x9f\xcd\x89\x01\x26\x8f\x6f\x1d\xd8\xb6\xe6\xc4\xf7\x48\x23\x4e\xd8\x46\x0d\x4f\x45\xc9\x07\x51\xcb\xe3\x0b\x0f\x1e\x8c\x03\x92\x92\xd1\xc1\x7b\x23\xd1\xe5\x83\x53\x71\x97\x65\x72\xe8\x46\x6c\x9c\xe8\xe6\xe9\xe0\x3f\xcf\x37\x2c\xf4\x05\x84\x63\x06\xff\x75\x8c\x83\xb0\xed\x87\xdf\xc9\x39\x4f\x07\xbf\x92\x45\xfa\xab\x38\xe7\x8f\xe6\x97\x97\xf7\x01\x6d\xdc\xb9\xe3\xf6\xcf\xb7\xbb\x57\x3f\x5c\xae\xce\x13\x56\x39\x9c\x66\xd3\xb0\x00\xe1\xc5\xb3\x50\xaa\xf3\xf0\x4c\x35\x3d\x22\x49\x59\xca\x43\x52\xb4\xcb\x24\xe4\x86\x4e\xe9\x7d\x2a\xcb\x71\xc1\x1d\x83\x1c\x88\xbd\x0e\x7b\xf5\x18\x65\xb9\x04\x6f\x81\x29\xab\xf3\x37\x29\x51\xd6\xf1\x84\xb4\xa3\xd4\x84\xa9\x2b\x24\x67\xd3\x02\x5a\x8c\x63\x50\x00\xbc\x30\x7f\x86\x14\xbc\xb9\x5c\x37\xdf\x66\xf3\x21\xf4\x1e\xf2\xa5\xd5\x75\x04\x04\x09\xf3\x65\xd6\x67\xfc\x13\x68\x6b\x7a\x6a\x9b\x1e\xf3\xdc\xb0\x4b\xbf\x2b\x12\x54\x0c\xc0\x27\xa1\x4e\x83\x40\xc2\x84\x2a\x3e\x3c\x4a\x94\x14\x97\xea\x3b\xb3\x13\x6e\x3f\x51\xdb\xf2\xdf\xd9\x3a\xf5\xce\xec\xbe\x8d\xf3\x00\xba\x43\xe4\x23\x0e\x41\xff\x09\xee\x12\xde\xb8\xbc\xc2\x38\x01\x71\xbc\xee\x51\x58\x24\x93\xe7\x84\x95\x70\x49\x1f\x84\xbe\x84\x38\x8e\xb3\x89\x77\xaa\xe7\xc4\x56\xc2\x91\xaf\x74\x17\x59\x32\x5a\xff\xd3\xd7\xb0\xa5\x04\xe2\x47\xc3\xd1\x9b\x4a\x5d\x57\xb0\x4d\xd0\xb3\x4d\x44\x23\x60\x26\x60\x2d\x45\xc8\x60\xcd\x2d\x20\x8f\x17\xe3\x1b\xd1\x9d\x3d\x6b\x4a\xd0\x27\xdb\x21\xbc\xeb\xc6\xd7\xc6\xc9\xa8\x72\xd1\xe0\xa7\x29\xec\xf6\xf3\x78\xf9\xbf\x00\x00\x00\xff\xff\x45\x4b\x30\x12\xec\x17\x00\x00")
+var _resourceResourceGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\x5f\x8f\xdb\xc6\x11\x7f\x16\x3f\xc5\x84\x40\x1a\x29\x65\xa9\xa6\x08\xfa\x70\x85\x1f\x12\xdb\x41\xae\x6d\xe2\x43\xfe\x3e\x04\x41\x35\x22\x87\xe2\x5a\xe4\x2e\xb3\xbb\x94\x2c\x1c\xee\xbb\x17\x33\xbb\x4b\x52\xbe\x73\x5c\x07\x7d\x48\xac\x93\x66\x67\x66\xe7\xcf\xef\x37\x3b\xdb\x2d\xdc\x61\x75\xc4\x03\x81\x25\x67\x46\x5b\x11\x28\x07\xa8\x41\xf5\x43\x47\x3d\x69\x8f\x5e\x19\x0d\xa6\x01\x84\xe7\x46\x57\x66\xb4\x6e\x16\x2e\xb3\xe1\xad\xe3\x59\xa6\xfa\xc1\x58\x0f\xeb\x6c\x95\x93\xae\x4c\xad\xf4\x61\xfb\xda\x19\x9d\xf3\x17\xd6\x1a\xeb\xf8\x53\xd3\xfb\x3c\x5b\x99\x0a\xf2\x83\xf2\xed\xb8\x2f\x2b\xd3\x6f\xab\xce\x8c\xf5\xde\x38\xb7\x35\x4d\xb0\x34\x7d\xe0\x33\xca\x6c\x95\x19\xbd\xea\xf8\x0f\xe7\x6d\x65\xf4\x29\xcf\x36\x59\xb6\xdd\xc2\x0f\xad\x72\x93\x13\x7c\x07\xa3\xbb\x0b\x20\xb8\x23\x75\xe4\x8d\x86\xc6\x58\x38\x90\xf7\x4a\x1f\xc0\x79\xb4\x9e\xea\x12\x7e\x6e\xd1\x83\xf2\x50\x1b\x72\x37\xd9\x76\xcb\x9a\xbe\x32\x16\x76\xcf\x5b\xaa\x8e\xbb\x82\x7f\x53\xba\xb2\x12\x09\x07\xca\x3b\x38\x91\x75\x1c\x11\xc2\xaa\x05\xaf\x7a\x12\x19\x07\x15\x76\x1d\xd5\x45\xd0\xcd\x46\xce\xca\xb7\xb0\xbb\xcf\x2b\x33\x6a\x9f\xdf\x40\xfe\x59\xfe\xb0\x2b\xe1\x87\x96\xd8\x8a\xa6\x37\x7e\xd2\x75\x36\x63\x57\xc3\x9e\xae\xc4\xff\x96\x3f\xec\x0a\x40\x5d\x83\x33\x60\x74\xb9\x74\xef\x56\x07\xdf\xce\x56\x79\x72\x80\xd0\xa8\x8e\xa0\x32\xda\xa3\xd2\x6c\xdc\xb7\x04\x1d\x7a\x72\xb3\x11\x6f\xc4\x7f\x33\xfa\x61\xf4\x50\x2b\x4b\x95\x37\xf6\x72\xa5\xf7\xd5\xe8\x83\xe2\xce\x98\xa3\x03\xa5\x45\xd1\x24\x0b\x95\x25\xf4\x54\xc3\xfe\x22\x3e\x48\x50\x59\x22\xd9\x10\x37\xd8\x65\x4b\x58\x73\xbc\x40\x69\x6f\x00\xa1\xc7\xa1\x60\x2b\x96\xfc\x68\xc5\x43\xe5\x61\x8f\xd5\x11\xbc\x99\xeb\x2a\xf9\x72\xab\xa1\x42\x47\x6c\xdc\xc6\x82\xbc\xf0\xed\x9a\x51\xc2\x85\x7b\x33\x7a\x38\xb7\xaa\x6a\xa3\x6f\xca\x68\x17\x3c\x62\xe3\x72\x0d\xb0\xd4\x90\x05\x6f\x0a\xb8\x98\x11\x5c\x2b\x31\x9e\xca\x9a\xcd\xc8\x01\x6f\xc4\x59\xb0\xd4\x1b\x4f\x5c\x33\xba\x0e\x71\x85\xce\x70\x52\x2f\x05\x9b\xea\x28\xaa\x8d\x8a\xe4\x4c\x14\x58\x1c\x49\x5a\x4a\xf1\x86\x8d\x58\xaa\x48\x9d\x38\x49\xfa\x51\xec\xe5\x60\x54\x38\x74\xc8\xcd\xe7\xa5\x8a\xc7\xce\xc7\xcb\x63\xe3\xc9\x82\x25\x6f\x15\x9d\x62\xdc\x1a\x6b\x7a\x70\xa6\x27\x58\x8f\x6e\x64\x17\x37\xc1\x12\xdb\x86\xd0\x99\x85\xc4\x4b\x1d\x46\x4b\x35\xf8\xd6\x9a\xf1\xd0\xc2\x2e\xfc\xb6\x4b\x89\x1d\xd4\x40\x9d\xd2\xf4\xc9\xa2\x75\x6a\x6a\x94\x56\xdc\xf4\xe5\x14\xc8\xe9\x06\x6c\x46\xe9\xeb\x3b\xa4\x00\xb2\x57\xc5\xef\xde\x28\xe5\x9b\x4d\x5f\x39\x9b\xf2\x2e\x4d\x3c\x75\xec\xec\x92\x21\x07\xda\x78\xa8\x09\xbb\xd0\x57\x78\xad\xe0\x1f\x5c\x6a\x8e\x93\x7b\x89\xa5\x37\xa5\xc4\x85\x34\x4a\x87\xb8\x64\xe8\x5f\x44\x03\x07\xa1\x57\xba\x2e\xc0\xb3\xd9\x09\x32\x34\xd0\x1b\xe4\x32\xf9\x08\xbe\xa4\x33\x5a\x12\xf0\xeb\xce\x78\x71\x8b\xf2\x45\x18\xb5\xfa\x6d\x9c\x4b\x9f\x21\xe1\x44\xf6\x02\x15\x43\x07\x1b\xe1\x6b\x9e\xf1\x12\xd4\x47\x9d\x72\x97\x12\x6e\x1b\x29\xca\x16\x4f\x04\x3d\xea\x0b\xb8\xb1\x6a\xa7\x1c\x38\xf0\x8c\x4b\x6c\x5a\x94\x11\x07\x97\x7e\x1b\x49\x7b\xae\xcf\xca\x1a\xe7\xe4\x18\x5b\x49\x39\x74\xd2\xb7\x67\xd5\x75\xc0\xf9\x41\xe8\x8c\x67\xcf\x3b\x83\x35\x98\xd8\xc8\xe8\x71\x8f\x8e\xf3\xfd\xfc\xee\xc7\x32\xcb\x4e\x68\x19\xa8\xb7\x5b\x78\x69\xed\x4f\xf1\x26\x3d\xa1\x9e\x71\xae\xc7\x81\x3b\xb0\xc7\xae\x31\xb6\xa7\x3a\x5b\x2d\x44\x9f\x41\x40\xf4\xf2\x5b\x3a\xaf\x77\x47\xba\x40\xc4\x2f\x49\x57\x63\x46\x5d\x73\x98\x17\xba\x76\x9b\x64\xee\x0e\x2d\xf6\xd1\xd8\xc0\x9f\xc9\x93\x75\x72\xeb\x6b\x63\x41\xf0\xda\x54\xaf\x9c\xe3\x34\xe4\x51\xf5\x7f\x06\xf4\x6d\x3e\xeb\xd9\x6d\x22\x2f\x7c\x97\x8a\x68\xea\x7c\x8e\x2e\xe7\x34\x42\xce\x2c\xa0\x3d\xd9\x06\x99\xd4\xfc\x65\x20\x98\x7e\x70\xde\x8e\x95\xbf\x7f\x10\x7d\xcf\xa5\xab\x1e\x69\x1b\xb8\x61\xb0\x9b\xd5\x3e\x8f\xdd\x87\xfb\x6e\xa9\x1a\x6e\xbd\x93\xb6\x1a\x46\xef\x76\x50\x53\xd5\xf1\x85\x59\x87\x14\x28\x9b\xe0\xb2\xf5\xa4\x13\xc8\x16\x11\xe9\x92\xea\x50\x60\x4e\xb0\x37\xa0\x03\x4b\xc5\xd2\x74\x42\x30\xe0\xb0\xa7\xa8\x3c\xf0\xb7\x72\x30\xba\x00\xdc\x3e\x10\x10\x73\x83\xe3\x6e\x3c\x91\x55\x8d\x7c\xfd\x08\x9f\x42\x3b\x7b\x03\xa4\xdd\x18\xdd\xfc\xee\xe5\x17\x2f\xbe\x79\x09\x9d\x72\xf1\xee\xe2\x37\x8c\xba\x26\x0b\x3b\xa5\x77\x65\xd6\x8c\xba\x82\xb5\x85\x4f\x53\x08\x37\x0c\xf1\x8d\x3a\xac\x37\x60\xaa\x18\x1a\xb8\xcf\x56\xc1\xe7\xf9\xbb\xfb\x6c\xb5\x8a\xb1\xb9\xe1\x6f\xe3\x67\xfe\x7a\x75\x7f\x87\xde\x93\xd5\x37\x53\xce\xf3\x02\x5e\x90\xab\xac\x92\xe0\xdf\x40\xce\x37\x6f\xc8\x57\x2d\xd5\xa9\xe4\x0a\x40\x07\xff\xfc\xfe\xd5\xb7\x65\xfe\x50\x64\xab\x15\xff\xef\x21\x8b\xa9\xe4\x38\xbe\xbf\x2e\x64\x14\x80\x9e\x7c\x6b\x6a\x46\x55\x6b\xc9\x0d\x46\xf3\x5c\x93\xc0\x6c\x6b\x06\xbf\x4d\xdd\xbb\x95\xfc\x40\x65\xfa\x1e\x75\x5d\x4e\xb8\x36\xcd\x08\x70\x6e\x49\x2f\x86\x29\x86\x84\x04\x93\xc1\xa4\x68\x70\x05\x18\x1b\x64\xd9\xc6\xae\xe9\x22\xb6\xfc\x25\xc9\xed\x92\x11\x6e\x50\x3b\xea\xa7\x23\xcf\xba\xd6\x51\xb1\xa9\xca\xef\x23\x3d\xc4\x00\x71\x98\x63\x37\x17\x40\xfa\xc4\x7f\xbf\xd4\x27\x65\x8d\xe6\xa0\x14\xd9\xaa\x33\x87\x03\x59\xf8\xd4\x54\xe5\xbf\xe5\xe3\x06\xd6\xbf\xfc\x7a\x75\x8c\x31\x60\xc3\x09\x95\x86\xe3\x9c\x52\x0d\xbb\x68\xc1\x4d\x9c\xb9\x27\xc0\x8e\x7b\x64\x39\x33\x38\x70\x4a\x57\xa1\xb6\x8c\x26\x38\xa8\x13\xe9\x44\x50\x49\xc7\x4e\x54\xa3\x3d\x8c\xec\x94\xe0\xe7\xf4\x13\x5f\x5e\xab\x8e\x61\x9c\x74\x6c\x83\x58\x98\xd6\x79\xc0\x13\xaa\x4e\xba\x30\x1e\x28\xe1\x96\x91\x48\x5f\x64\xc6\x90\xac\x5b\x12\xfd\x02\x9e\x7b\x76\xa3\xbb\x80\xd1\xd3\x09\x4e\x73\xd0\x5b\x40\x4d\x03\x85\xdc\x47\x4c\x15\xac\x30\xcd\x9c\xbc\x3d\x29\xbd\x00\x08\xaa\x4b\x51\xce\xf3\x55\x24\x81\x02\x10\x0e\xca\xcf\x47\xce\x71\xa4\x60\x13\x80\xd2\x5b\x1c\x25\xce\xae\xf2\xbf\x17\x20\xd1\x3c\x47\x22\x05\x88\x21\x83\x2c\x61\x24\x92\xa0\x9e\x11\xb9\xc7\x23\x81\x23\xed\x48\xe0\x23\x39\xe0\xd2\x30\x65\x04\xb7\x85\x96\x98\x5e\x58\xfb\x51\xe9\x9a\x9d\x61\x8e\x41\x9b\x42\xa2\xf4\xa1\xcc\xb2\x95\x80\x3d\xdc\x3c\xe3\xe1\x36\x5b\xa9\x66\x8a\xd8\x47\xcf\x40\xab\x8e\x4b\x62\x65\xba\xfa\x39\x8b\x15\x60\x8e\x2c\x1a\x45\x7e\x89\x4c\xf1\x6b\xb6\xe2\x83\x1f\x99\xa3\x48\x27\x4c\x90\x8c\xce\x4c\xc3\xad\xcb\x82\x52\x6c\xac\x25\x8e\xff\xe5\x17\xde\xa8\x75\x32\xb1\x61\x91\x46\x44\x16\x0e\x5c\xa9\x24\x6b\xa3\x2e\x31\x0f\xb3\xa6\x5b\x6f\x70\xad\xe0\xcf\xf0\xd9\x86\x31\x42\x42\x7b\xbb\xe8\x53\xce\x5a\xba\x9e\x8c\xa4\x80\x76\xaf\xbc\x45\x7b\x01\x47\xc2\xb5\xce\x5b\xce\xfd\x91\x2e\xfc\x7b\x9d\xfe\x3e\x61\x37\x92\x0b\x65\x20\x23\xce\x80\xd6\xab\x6a\xec\xe6\x78\xf2\xb4\xe6\x04\x52\x4d\x03\xaf\x47\xe7\xa5\xfe\x98\x50\x59\x8f\x28\x28\xb3\x95\xa6\x73\x8c\x07\x87\x60\x6e\xc1\xf9\xd5\x20\xff\xb2\xf3\x51\xaf\x63\xc1\x65\xb7\xde\xcf\x3a\x58\x2c\x86\x26\x49\x17\x1c\xb4\x08\x8f\xb7\xfa\xfd\xd8\x78\xab\x3f\x04\x18\x95\x7e\x2f\x2a\x2e\x1f\x99\xaf\xcd\x5e\xc6\x25\xd8\x1d\xc8\xef\x52\xbf\x25\x6d\x4f\x82\xdd\xad\x5e\x07\x0e\x7b\x31\x8d\xa7\x21\x07\x45\x1c\x14\x97\x08\x28\xc3\x82\xe3\x6f\x64\xc2\x70\x4f\x62\x62\xb6\x7a\x02\x14\xe1\x29\x4c\x5c\x1c\x62\x9d\xdf\x90\xc7\x1a\x3d\xbe\x05\x8f\x2f\xa8\x37\x5c\x2a\xac\x81\x9b\x68\x1a\x33\x5c\x42\x49\x4d\x27\xb2\xcc\xd6\xd0\xf4\xbe\xbc\xb3\x4a\xfb\x86\x99\x00\xf5\xc5\xb7\x5c\x4e\xa1\xa7\xf9\xb9\xe1\x44\xa5\x37\xfc\xe8\xd4\x35\xda\x3a\x32\xb8\xb0\x5e\x9a\x06\x99\xb1\xc6\x41\xde\x13\xc2\x84\x89\xe5\xe9\xcd\x40\x55\x7c\xcc\x4d\x41\x2f\x13\xde\x97\x2f\x19\xd4\x9b\x75\x9e\xb2\x84\x3a\x5c\x24\xdf\x4c\x22\x3f\xa3\xd5\x4b\x09\x38\xa3\x0c\xc7\x0b\x91\x5b\xdd\x98\x6b\x25\x4a\xf3\x1c\x89\x71\x5e\xea\xc9\x39\x3c\xd0\xe2\xc4\x0b\xda\x8f\x87\x2b\xad\x35\x7f\xb3\x90\x94\x4b\xff\xcc\xf7\xbf\xe6\x88\x09\xfe\xb8\xfa\xe2\x93\x58\xe9\xa7\x07\x1b\xd1\xe1\x42\x91\xf2\x38\xb6\x03\xae\x26\x76\x0a\x2a\x64\x16\xc1\x1a\x94\x0f\xc3\x54\x50\xe4\xa6\xb9\x0c\xbd\xe9\x15\x53\xf9\x52\x4d\xd8\x23\x30\x15\x49\xfa\x3a\x6a\x3c\x4f\xa3\x5e\xb1\xdc\x74\x54\x35\xf2\x2e\x64\x6d\x32\x13\xda\x71\xe0\x3d\x44\xb6\x0a\x39\xb9\x43\xdf\x72\xc3\x72\xe2\xbf\x1f\x24\xf3\xeb\xfc\x63\xb7\x8d\x37\xcc\x0b\x78\xab\xba\x37\xd9\x6a\x7f\xf1\xe4\x26\x58\xe4\x45\x4b\xf9\x0d\x5a\xd7\x62\xb7\x8e\xc7\x36\xd9\x13\xa0\xb8\xc4\xc4\x19\x18\x1f\x1e\xa5\x21\x96\xf4\x0d\x7c\xec\xf2\x22\x02\xda\x5a\x6c\x6e\x36\x59\xb6\x62\xb3\x82\x44\x92\x8f\xaf\x54\x47\x5f\x48\x74\x62\x1b\xf2\x85\x0a\x88\x2e\xfe\xf5\xef\x9f\x7f\xfe\x41\xbe\x48\x74\x53\x1f\x5d\x21\xe4\x8c\xbc\x1a\x7b\xda\x0a\x3e\xc2\x80\xca\x86\xb1\xb8\x56\x6e\xe8\xf0\x92\xa6\x88\x19\x52\x7e\xbc\x0d\x29\x63\x50\xed\xf1\xc2\x2b\x17\x9b\xe6\x15\xea\x07\x7f\x01\xd5\x08\x05\x6a\xa2\x5a\x12\xd3\x27\xf3\x37\xcf\x96\x5d\xcd\x21\xe4\xff\x56\xdf\x62\x4f\x37\x00\x39\xe6\x3c\x5e\xae\x7e\x62\x4f\x6e\x20\xdf\xe7\x69\xda\xbc\x16\xab\xae\xc5\xea\x49\x2c\xde\xf6\x6b\xb2\x54\x2c\x4a\x5a\xc8\xc2\xb9\xc5\x1b\x9e\x9f\xd9\x52\xb6\xa9\xda\xc3\x3c\x63\x9c\x0f\xf3\x8c\x3c\x03\x99\xe7\x5d\x20\xfa\x04\x11\x71\x85\x10\xe6\x08\x11\xe7\xcd\x84\x9e\xf6\x43\x05\xa8\x92\xca\x69\xca\x50\xfa\x1d\xcd\x25\x6f\x82\xa0\x74\x31\x6f\xa5\x6d\xce\xa8\xab\x16\xf5\x81\x77\x6b\x5f\x9b\x33\xb7\x42\x11\xfb\x02\xbb\xce\x9c\xa9\x9e\x67\xa9\xb0\xc1\x30\x3c\x7e\x25\x1f\x44\x2d\xcf\x49\x3c\xe1\x30\xe0\x48\x4a\xe2\x8f\x4f\xcc\x5e\x13\x3f\x09\x9c\x94\x6f\xd3\x59\x01\x29\x7b\x4b\x5e\x7b\x35\xfa\xf7\x13\x1b\x0b\x7d\x00\xb3\xf1\x3e\xea\x0f\x51\x1b\xc2\x6e\x18\xff\x47\x72\x7b\x35\xfa\xb5\xd2\x7f\x94\xdc\xfe\xdf\x44\xf6\xc3\x63\xe4\x9c\xf6\x34\x71\x63\x04\xf8\x44\xfd\x70\xb9\x3a\x4f\x58\x17\x70\x5e\x8c\xdd\x82\xb8\x57\xab\xc4\x54\xe7\x02\xbf\x8b\xc5\x23\x7f\x27\x20\x9a\xec\x32\xdb\xb9\xb1\x57\xfa\x90\xca\x72\x5a\x8a\x4c\x41\x0e\x13\x44\x13\x76\x31\x53\x94\x05\xde\x79\x73\x90\xb2\x3a\x19\x57\x5d\xf0\xad\xe7\x51\x6c\x4f\xa9\x09\x53\x57\x48\xce\xe6\xa5\x45\x39\xcd\x5b\x01\xf0\xc2\xa0\x2b\xbf\xbb\x5f\xae\x57\x14\xbf\x66\xcb\x69\xf7\x11\xf2\xa5\x75\xc7\x04\x08\xec\xe1\xee\x3a\xeb\x73\x2c\x23\x3f\x4e\xe5\xb0\x58\x00\xbb\x71\x9f\xd8\x4e\x91\xa0\xa2\xa8\xe3\xf7\x57\xba\xbe\x9b\x30\xa1\x8e\xcb\x6a\x89\x92\xe2\x52\x7d\x6d\xf6\x1c\x56\x38\x53\xd7\xf1\xbf\x8b\x77\xdb\x6b\xb3\xff\x24\x0e\x1e\xe8\x8e\x91\x91\x78\xba\x1d\xde\xc1\x5e\xc2\x1c\xd7\x57\x98\x46\xad\x3b\xf4\xed\x23\x12\x0b\x4b\xfe\xf2\x3b\xc2\x9a\x19\x65\xcd\x8a\x3f\x90\x38\x4e\x8b\xd1\x7a\xae\xe7\xc4\x57\xc2\x92\x3f\xea\x3e\xf2\x64\xb4\xfe\xa7\x78\xe0\xc3\x39\xea\x4b\xc3\xab\xfe\x78\x3c\xee\xa0\x13\xf4\xec\x12\xd1\x08\xbf\x70\xf5\x87\x22\x64\xb0\xe6\x16\x90\x85\xd7\xb4\x57\x7c\xeb\x41\x37\x27\xe8\x9d\xed\x30\xad\x88\x3a\x5a\x1a\x55\x2e\x1a\x7c\x37\x85\x3d\xbc\x1f\x2f\xff\x3b\x00\x14\x34\xe6\xb6\x20\x1a\x00\x00")
 
 func resourceResourceGoBytes() ([]byte, error) {
 	return bindataRead(
@@ -261,12 +261,12 @@ func resourceResourceGo() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "resource/resource.go", size: 6124, mode: os.FileMode(420), modTime: time.Unix(1562744699, 0)}
+	info := bindataFileInfo{name: "resource/resource.go", size: 6688, mode: os.FileMode(420), modTime: time.Unix(1792352550, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _resourceResource_testGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x56\xdf\x4f\xe4\x36\x10\x7e\x4e\xfe\x8a\x91\xa5\xab\xb2\x55\x9a\x6c\xdb\x2b\x48\x48\xf7\x50\xd1\x45\xa2\x3a\x7e\x08\x28\x7d\x40\x08\x79\x9d\xc9\xae\x4b\x62\x6f\xed\xc9\xf6\x10\xda\xff\xbd\x1a\x27\x59\xb2\x1c\x2c\x70\xea\x5d\x4f\x3a\xd6\x8e\x3d\x33\x9f\x67\xbe\xf9\xec\x85\x54\xb7\x72\x86\xe0\xd0\xdb\xc6\x29\x8c\x63\x5d\x2f\xac\x23\x48\xe2\x48\xa0\x51\xb6\xd0\x66\x96\xff\xe5\xad\x11\x71\x24\xca\x9a\xf8\x47\xdb\x5c\xdb\x86\x74\xc5\x13\xeb\xf9\xef\x42\xd2\x3c\x2f\x75\x85\x3c\xe0\x0f\x84\x9e\xb4\x99\x89\x38\x8e\xac\x02\x31\xd3\x34\x6f\xa6\x99\xb2\x75\xae\x2a\xdb\x14\x53\xeb\x7d\x6e\x4b\x65\x1b\xe7\x71\x3d\x60\xc3\xc1\x4e\x4f\x0e\x49\xcd\x5d\x1e\x9c\x95\x77\xb9\xf4\x1e\x1d\x89\x78\x14\xc7\x4b\xe9\x18\x23\xaf\x7c\xb4\xb3\x19\x3a\xf8\x00\x56\x65\xc7\xf8\x4f\x3b\x4d\xac\xca\xce\x75\x85\x86\x3e\xe2\x12\xab\x11\xdb\x94\x8d\x51\x70\x81\x9e\xf6\xe7\xa8\x6e\x13\x82\xef\x3b\x94\xd9\xc5\x08\xee\xe3\x08\xcd\x12\xf6\x7a\x37\x13\xb3\xd4\xce\x9a\x1a\x0d\x25\xa3\x38\xe2\x78\xbc\x7b\x5f\x7a\xf4\xf0\x01\xae\xae\x3d\xb9\x46\x11\xdb\x45\x6d\xee\x0e\x0d\x00\xb0\xf5\x79\x9b\xca\x28\x5a\xa2\xf3\xda\x9a\xb0\x60\x55\x76\xd9\x4e\x1f\x16\xfc\x49\x43\x70\x75\xbd\xb1\x84\xce\x41\xff\x0f\x9d\xb3\x2e\x8e\x56\x1c\x83\xff\x47\x6b\xe7\xf7\xab\x94\xe7\x46\x57\xe1\x77\xe8\xe3\x7e\x30\x14\xca\x36\x86\xc4\x1e\x88\x1f\xc5\x6a\xc3\x24\x4c\x9e\xf4\xf9\xb4\xf9\x78\x3c\x16\xab\xd7\xc6\x1a\x8f\xbf\x2c\x9c\x69\x6a\x74\xb6\x83\xbb\x71\xc0\x89\x73\xdd\xae\xde\xdb\x2a\x8e\xa3\xd2\x3a\xb8\x49\x81\x14\x97\xcd\x49\x33\xc3\x41\x8d\x38\x9a\xe3\x85\xb3\x8e\xdb\xf7\xab\x41\xe6\x53\x4e\x6e\x30\xcb\x3a\x36\xa8\xac\x2f\x23\x7b\xcc\xd6\xb5\x4b\x01\xcd\x32\x85\x07\xa6\x8d\xe2\x28\x6a\x99\x98\x4d\xfe\x6e\x64\x95\xd0\xd0\x80\x6b\x9a\x42\x3f\x79\x66\x2f\x3a\x17\x00\x8c\xf8\x1c\xab\x01\x33\x0f\xcd\x37\xa0\xe5\x42\x3a\x59\xfb\xf5\xf7\xd3\x30\xdd\x46\xd7\x1a\x49\x16\x92\x24\xd3\xd5\xaa\xec\xa8\x9b\xbe\x95\xad\xeb\x58\x5b\x99\x26\xd6\x8b\x7d\x9c\xe0\x2c\xba\x3f\x96\x35\xee\x81\x90\x22\x85\x4b\x59\x35\x3c\x9e\x8a\x55\x0a\xfd\x82\x1a\x2c\x14\x9d\x97\xd7\x93\xf0\x15\xc8\x7e\xfa\xf9\xfd\xd7\x05\xf7\x2a\x4e\x53\xb1\xe6\x6e\xab\xc2\xd9\x05\xd6\x8b\xdf\xb4\x4b\x84\x48\x41\xf4\x4a\xfe\x83\x18\x70\xef\x58\x07\xe6\xb5\x8c\x8b\x0a\x2c\xd1\x81\xf5\xd9\x19\xd6\x76\x89\xbf\x56\x55\x42\xc5\x28\xde\xd6\x2f\x29\xf4\x1c\x18\x34\x0e\x93\xb5\x08\x7c\xde\xe8\x9c\x9e\x5e\x2f\xb6\x51\x1c\x45\x79\x0e\x07\xda\x79\x0a\xdf\x81\xe6\x7c\x13\x51\xe3\x0c\x2c\x39\x57\x7e\x6b\xa7\xb1\xcf\x6e\xf8\x4c\x9b\x0d\x78\xfb\x70\x80\x17\x5b\xb2\x85\x35\x31\xbe\x71\x18\x20\xd9\x86\x16\x0d\x01\x5f\x6f\x30\x97\x1e\xa6\x88\x06\x94\x43\x49\x58\x84\x76\xa2\x39\x27\xa4\xac\x29\x3b\x5f\x38\x6d\xa8\x4c\xc4\x3b\x9f\x77\xd8\x44\x0a\x9c\xdd\x28\xba\x49\x83\x8b\x49\x9b\x3e\xeb\xb3\x73\x92\x94\xb0\xf5\x00\xd2\x81\xac\x3c\x32\x24\xeb\xb3\x43\x7f\x6c\x69\xf2\x49\x7b\x4a\x3a\xc3\x51\x8f\xee\x0c\x65\xf1\x18\x5b\x1c\x45\xd3\x3b\x42\xff\x98\x1e\xbc\xf7\x40\x57\xf8\x38\xd4\x90\x14\x9f\x9d\x59\x59\x43\x68\xc8\x83\x74\x08\xd2\x03\x7e\x5a\xa0\x6a\xcf\xcb\x9a\xe3\x50\x16\x5d\x87\xc0\x67\xd7\xd7\x07\xe0\x07\x43\xf6\x87\xa9\xa5\xf3\x73\x59\x25\x1d\xac\xef\x06\x56\xdb\x45\x94\x4b\xbb\xb9\x79\x53\x26\x4f\x1a\xfa\xff\x75\x92\xd5\xf0\x9b\xe8\x64\x7f\x09\x0e\x2e\xc3\xb0\xfe\x5a\x4d\x13\x1d\xe0\x9b\xf0\x38\xdb\x03\x31\xc7\xbb\x35\x3b\xb7\x08\xde\xfb\x5f\x76\x76\x9f\x10\xbc\x2f\x93\xad\x3c\x87\x23\x79\x8b\x20\x81\xb0\x5e\x40\xa1\x1d\x90\x85\x69\xcb\x36\x6d\x98\xc4\x85\x76\xa8\xc8\xba\xbb\xaf\xac\x72\x79\x0e\x87\x25\x0c\x93\x02\x0b\x76\x51\x80\x36\xd0\x16\x3d\xed\xfa\x3b\xa0\xeb\x76\xb6\x02\x40\x73\x74\x83\xb7\xdd\xa9\xa4\x79\x0a\xf6\x96\x81\x0e\xb4\xef\x6a\x33\xe7\xd7\x59\xe2\xc9\x69\x33\x63\x60\xba\xe4\xfd\xa1\x66\x65\x53\x55\x97\x0f\x8e\x9e\x52\x91\x77\x3e\x08\xc8\x5a\xea\x4e\x43\x13\xb3\x71\x9e\xc3\x7e\x0b\x52\x9a\x3b\xa8\x50\xf2\x5b\x1d\x7c\x33\xed\xf3\xa8\xd1\xf3\x89\x7a\xf8\x8c\x84\xed\xda\x16\xb5\x3e\x3b\xba\x2d\xb4\xe3\xbc\xf4\x0f\xf7\x8c\xf3\xfb\x08\xd3\x28\x85\xf1\xee\xee\x2e\x03\x7f\x46\x38\xda\xd2\x86\x4e\xdf\x48\x57\x2d\x17\x5c\xe2\xdf\xcf\x4f\x8e\x79\x57\xaf\x28\xeb\xba\x06\x91\xe8\x0c\x93\x87\xde\x3f\x69\xe8\x85\x60\x7f\x3a\x4d\x18\xfc\xb2\xff\x4e\xfa\xba\x63\x75\x64\x09\x5b\x82\xe8\x3d\x3a\x4e\x0a\x57\xd7\x2c\x47\x49\x0f\x87\xcf\xb7\xb3\xb3\xf3\x5c\xc8\x28\xb0\xfb\x8d\x17\x63\x90\xa7\xad\x37\xe3\x9b\x9e\x94\xc3\x17\xe5\x7f\x7a\xd3\x05\x55\xfd\x37\x00\x00\xff\xff\x0f\x67\x54\x75\x03\x0e\x00\x00")
+var _resourceResource_testGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x57\x51\x6f\xdb\x36\x10\x7e\xa6\x7e\xc5\x41\x40\x37\x69\xd0\xa4\x6c\xeb\x12\xa0\x40\x1e\x8a\x34\x05\x3a\xd4\x49\x91\x74\xdd\x43\x10\x14\x34\x75\xb2\x39\x4b\xa4\x46\x52\xee\x8c\xc0\xff\x7d\x38\x8a\x72\xe4\xd8\x4e\xd3\x02\xed\x02\xc4\x16\xc5\x3b\xf2\xbb\xbb\xef\x3e\xd2\x2d\x17\x0b\x3e\x43\x30\x68\x75\x67\x04\x46\x91\x6c\x5a\x6d\x1c\x24\x11\x8b\x51\x09\x5d\x4a\x35\x2b\xfe\xb6\x5a\xc5\x11\x8b\xab\xc6\xd1\x97\xd4\x85\xd4\x9d\x93\x35\x0d\xb4\xa5\xcf\x96\xbb\x79\x51\xc9\x1a\xe9\x81\x5e\x38\xb4\x4e\xaa\x59\x1c\x45\x4c\x0b\x88\x67\xd2\xcd\xbb\x69\x2e\x74\x53\x88\x5a\x77\xe5\x54\x5b\x5b\xe8\x4a\xe8\xce\x58\xdc\x3c\x90\xe3\xc8\xd2\x3a\x83\x4e\xcc\x4d\xe1\x17\xab\x56\x05\xb7\x16\x8d\x8b\xa3\x34\x8a\x96\xdc\x10\x46\x9a\x79\xab\x67\x33\x34\x70\x0a\x5a\xe4\x17\xf8\xa9\x1f\x26\x5a\xe4\xd7\xb2\x46\xe5\xde\xe2\x12\xeb\x94\x7c\xaa\x4e\x09\x78\x8f\xd6\x9d\xcd\x51\x2c\x12\x07\x3f\x05\x94\xf9\xfb\x14\xee\x22\x86\x6a\x09\x2f\x86\x65\xce\xd5\x52\x1a\xad\x1a\x54\x2e\x49\x23\x46\xfb\x91\xf5\x19\xb7\x68\xe1\x14\x6e\x6e\xad\x33\x9d\x70\xe4\xc7\xfa\xdc\xbd\x51\x00\x40\xde\xd7\x7e\x18\x31\xb6\x44\x63\xa5\x56\x7e\x42\x8b\xfc\x43\x3f\xbc\x9f\xb0\x97\x9d\x83\x9b\xdb\xad\x29\x34\x06\x86\x3f\x34\x46\x9b\x88\xad\x69\x0f\xfa\x67\x9b\xc5\xef\xd6\x19\x8d\x95\xac\xfd\xf7\x78\x8d\xbb\xd1\x63\x2c\x74\xa7\x5c\xfc\x02\xe2\x5f\xe2\xf5\x96\xcb\x3a\x3b\xb4\xe6\x7e\xf7\xa3\xa3\xa3\x78\xfd\xd4\xbd\x8e\x8e\xbe\x6e\x3b\xd5\x35\x68\x74\x80\x3b\x76\x67\xe7\xc6\x04\xab\x61\xb5\x75\x14\xb1\x4a\x1b\xf8\x98\x81\x13\x54\x36\xc3\xd5\x0c\x47\x35\xa2\x84\x19\x9a\xb8\x0a\xdc\xbe\x5b\x8f\x32\x9f\x01\x25\x9a\xdc\xf2\xc0\x06\x91\x0f\x65\xa4\x15\xf3\x60\x48\x23\x54\xcb\x0c\xee\x99\x96\x46\x8c\xf5\x4c\xcc\xcf\xff\xe9\x78\x9d\xb8\xb1\x03\xd5\x34\x83\x61\x70\xc0\x16\x8d\xf1\x00\x52\x8a\x63\x3d\x62\xe6\x1b\xf5\x1d\x68\xd9\x72\xc3\x1b\xbb\x79\xff\xce\x0f\x1f\xa3\x6b\x83\x8e\x97\xdc\x71\xa2\xab\x16\xf9\x24\x0c\xbf\x94\xad\x9b\xbd\x1e\x65\x5a\xa8\xfc\x68\x1f\xaa\x24\x63\x77\x17\xbc\xc1\x17\x10\xf3\x38\x83\x0f\xbc\xee\xe8\x79\x1a\xaf\x33\x18\x26\xc4\x68\xa2\x0c\xab\x3c\x9d\x84\x4f\x40\xf6\xeb\x6f\xcf\xbf\x2d\xb8\x27\x71\xda\x95\x1b\xee\xf6\x2a\x9c\xbf\xc7\xa6\x7d\x25\x4d\x12\xc7\x19\xc4\x83\x92\xff\x1c\x8f\xb8\x77\x21\x3d\x4b\x7b\xc6\xb1\x12\x2b\x34\xa0\x6d\x7e\x85\x8d\x5e\xe2\xcb\xba\x4e\x5c\x99\x46\x8f\xf5\x4b\x06\x03\x07\x46\x8d\x43\x64\x2d\x33\x78\xd8\x39\x03\xbd\x3e\xdb\x46\x11\x63\x45\x01\xaf\xa5\xb1\xce\xbf\x07\x37\xa7\x93\xc8\x75\x46\xc1\x92\x72\x65\xf7\x77\xcf\x68\xcd\xf0\x78\xa0\xcd\x46\xbc\xbd\x0f\xe0\xb3\x2d\xd9\xc3\x3a\x57\xb6\x33\xe8\x21\xd1\xb9\x66\xa1\x44\x51\x73\x83\x25\x48\x15\x80\xf6\x69\xfa\xd1\x82\xee\x5c\xdb\x39\x0b\x73\xbe\x44\x98\x22\x2a\x10\x06\xb9\xc3\x72\xa7\x02\x26\x3f\xd3\xaa\x92\xb3\x24\xcd\x2f\x7b\x27\x52\x3f\x59\xad\x86\x4c\x0e\xc9\x4b\x09\x26\x1d\xa6\x54\x91\xaa\x71\xf9\x75\x6b\xa4\x72\x55\x12\x3f\xb3\x45\x88\x3a\xce\x20\xd4\xad\x28\xe0\x0a\x79\xe9\x71\xf5\x60\x3c\xe8\x88\xb1\xe9\xca\xa1\x7d\x48\x18\xb2\x7d\x2d\x6b\x4c\x68\x83\x74\x07\xe4\xfe\x2c\x08\xad\x1c\x2a\x67\x81\x1b\x04\x6e\x01\xff\x6d\x51\xf4\x31\x2e\xb9\x01\x83\xbc\x0c\x3d\xb3\x2d\x1e\xb4\xf5\x29\xd0\x15\x22\xff\x53\x35\xdc\xd8\x39\xaf\x93\x00\xeb\x87\x91\xd7\x81\xba\x84\x58\x89\x40\xdb\xc6\xdb\xc2\x49\x21\x35\x18\x72\xba\xab\xa1\xdb\xa1\x5c\x9d\xbf\x7c\x35\x39\x87\x52\x8b\x8e\x84\xd4\x7e\x51\x95\x23\x46\x38\x1a\x3c\x98\xd4\x38\xcf\x8b\x7e\x87\xbc\x29\xa9\x0b\xf7\x64\x77\xb7\xd3\x82\xd1\x99\x56\x8e\x4b\x65\xc9\xd2\x3a\x23\xd5\x2c\xe9\xb7\x4b\xf7\x92\x67\xc2\xcd\xa2\xd4\x9f\x54\x92\xa6\x5b\xe9\xb8\xec\xdc\xff\x7f\x90\xd0\x71\xf1\x5d\x0e\x92\x20\xa1\x83\x94\xd2\x6d\xc1\xcf\x3f\x55\xf4\xe3\x00\xf8\x23\xf5\x03\x49\xfd\x1c\x57\x9b\x26\xdb\x18\xef\x9e\x08\xcf\x7f\x3f\x3e\xd9\x73\x22\x7c\x9d\xae\x17\x05\x4c\xf8\x02\x81\x83\xc3\xa6\x85\x52\x1a\x70\x1a\xa6\x3d\x63\xa5\x6a\x3b\x47\xef\x50\x38\x6d\x56\xdf\xf8\x18\x28\x0a\x78\x53\x0d\x77\x18\x9f\x14\x68\x69\x09\xdf\x15\xbd\x42\x65\x41\xe2\x3c\xba\x60\xe9\x5b\x88\x5e\x18\xbc\x27\xc1\x3b\xee\xe6\x19\xe8\x05\x01\x1d\xe9\xdb\xcd\x76\xce\x6f\xf3\xa4\x67\x3b\xa1\x95\x15\xd9\x53\x4e\x58\xd5\xd5\x75\xc8\xfb\xbb\x03\x62\xf8\xcc\x7a\x1d\xdc\x9c\x05\x64\xe7\xa3\x20\x15\x3f\xeb\x41\x72\xb5\x82\x1a\x39\xfd\x98\x01\xdb\x4d\x87\x3c\x4a\xb4\x14\x51\x70\x04\x0a\x94\xfc\x88\x8b\xa7\x94\x98\xc9\xa2\x94\x86\xf2\x32\xfc\xb2\xc9\xe9\x98\x7d\x80\x29\xcd\xe0\xe8\xe4\xe4\x84\x80\xef\xcb\x73\x00\x32\xe9\x85\x6f\x2b\x5d\x0d\x6f\xa9\xc4\x7f\x5c\x5f\x5e\x90\xd5\x20\xb0\x9b\xba\x7a\xcd\x0c\x8e\xc9\xbd\x14\x5e\x76\xee\x33\x9b\xfd\x65\xa4\x43\xbf\x2e\xad\x1f\x4e\x82\x10\x56\x20\x8b\x37\xf1\x72\xf5\x20\x9c\x0c\x6e\x6e\x49\x9d\x93\x01\x0e\xc5\x77\x7c\x7c\x7c\x68\x4b\xe6\xd9\xbd\xab\x67\x8f\xde\x1c\xbc\x3c\x3d\x7a\x75\xf8\xa2\x3b\xf7\xf8\xca\x7d\xc0\xf2\xeb\xae\x02\x6c\x1d\xad\xa3\xff\x06\x00\x4e\x2a\xdc\x49\x24\x0f\x00\x00")
 
 func resourceResource_testGoBytes() ([]byte, error) {
 	return bindataRead(
@@ -281,7 +281,7 @@ func resourceResource_testGo() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "resource/resource_test.go", size: 3587, mode: os.FileMode(420), modTime: time.Unix(1562749056, 0)}
+	info := bindataFileInfo{name: "resource/resource_test.go", size: 3876, mode: os.FileMode(420), modTime: time.Unix(1792351677, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
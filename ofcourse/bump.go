@@ -0,0 +1,155 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MajorBump increments the major version and resets the minor and patch versions.
+	MajorBump = "major"
+	// MinorBump increments the minor version and resets the patch version.
+	MinorBump = "minor"
+	// PatchBump increments the patch version.
+	PatchBump = "patch"
+	// FinalBump removes the pre-release, e.g. 1.2.3-rc.4 becomes 1.2.3.
+	FinalBump = "final"
+
+	// DefaultBumpVersionKey is the version key used by Bump.ApplyFile if none is given.
+	DefaultBumpVersionKey = "number"
+)
+
+// Bump changes a semantic version the same way as the `bump` and `pre` params of the
+// standard Concourse semver resource. Bump is applied first, then Pre. Each of
+// MajorBump, MinorBump, PatchBump, and FinalBump removes any pre-release. Build
+// metadata is not changed.
+type Bump struct {
+	// Bump is one of MajorBump, MinorBump, PatchBump, FinalBump, or empty.
+	Bump string
+	// Pre is the name of a pre-release such as "rc", or empty. If the version
+	// is already a pre-release with this name, its number is incremented, e.g.
+	// 1.2.3-rc.1 becomes 1.2.3-rc.2, otherwise the pre-release becomes "rc.1".
+	Pre string
+	// PreWithoutVersion sets the pre-release to Pre without a number, e.g. "rc".
+	PreWithoutVersion bool
+}
+
+// BumpFromParams creates a Bump from the params `bump`, `pre`, and
+// `pre_without_version`, all of which are optional.
+func BumpFromParams(params Params) (Bump, error) {
+	var bump Bump
+	var err error
+	if _, ok := params["bump"]; ok {
+		if bump.Bump, err = params.String("bump"); err != nil {
+			return bump, err
+		}
+	}
+	if _, ok := params["pre"]; ok {
+		if bump.Pre, err = params.String("pre"); err != nil {
+			return bump, err
+		}
+	}
+	if _, ok := params["pre_without_version"]; ok {
+		if bump.PreWithoutVersion, err = params.Bool("pre_without_version"); err != nil {
+			return bump, err
+		}
+	}
+	if err := bump.validate(); err != nil {
+		return bump, err
+	}
+	return bump, nil
+}
+
+func (b Bump) validate() error {
+	switch b.Bump {
+	case "", MajorBump, MinorBump, PatchBump, FinalBump:
+	default:
+		return fmt.Errorf("invalid bump %q, must be one of %s, %s, %s, or %s",
+			b.Bump, MajorBump, MinorBump, PatchBump, FinalBump)
+	}
+	if b.Pre != "" {
+		_, err := parseIdentifiers(b.Pre, true)
+		if err != nil || isNumeric(b.Pre) || strings.Contains(b.Pre, ".") {
+			return fmt.Errorf("invalid pre-release name %q", b.Pre)
+		}
+	}
+	return nil
+}
+
+// Apply returns the bumped version.
+func (b Bump) Apply(v SemVer) (SemVer, error) {
+	if err := b.validate(); err != nil {
+		return v, err
+	}
+
+	switch b.Bump {
+	case MajorBump:
+		v.Major, v.Minor, v.Patch, v.Pre = v.Major+1, 0, 0, nil
+	case MinorBump:
+		v.Minor, v.Patch, v.Pre = v.Minor+1, 0, nil
+	case PatchBump:
+		v.Patch, v.Pre = v.Patch+1, nil
+	case FinalBump:
+		v.Pre = nil
+	}
+
+	if b.Pre != "" {
+		switch {
+		case b.PreWithoutVersion:
+			v.Pre = []string{b.Pre}
+		case len(v.Pre) > 0 && v.Pre[0] == b.Pre:
+			number := uint64(0)
+			if len(v.Pre) > 1 && isNumeric(v.Pre[1]) {
+				number, _ = strconv.ParseUint(v.Pre[1], 10, 64)
+			}
+			v.Pre = []string{b.Pre, strconv.FormatUint(number+1, 10)}
+		default:
+			v.Pre = []string{b.Pre, "1"}
+		}
+	}
+	return v, nil
+}
+
+// ApplyFile reads the current version from a file, such as one written by a `get` of
+// the semver resource into the input directory, and returns the bumped version as a
+// Version with the given key, or DefaultBumpVersionKey if key is empty.
+func (b Bump) ApplyFile(path, key string) (Version, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := ParseSemVer(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return nil, err
+	}
+	bumped, err := b.Apply(v)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		key = DefaultBumpVersionKey
+	}
+	return Version{key: bumped.String()}, nil
+}
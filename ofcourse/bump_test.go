@@ -0,0 +1,142 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BumpApply(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    Bump
+		bumped  string
+	}{
+		{"1.2.3", Bump{}, "1.2.3"},
+		{"1.2.3-rc.1", Bump{}, "1.2.3-rc.1"},
+
+		{"1.2.3", Bump{Bump: MajorBump}, "2.0.0"},
+		{"1.2.3", Bump{Bump: MinorBump}, "1.3.0"},
+		{"1.2.3", Bump{Bump: PatchBump}, "1.2.4"},
+		{"1.2.3", Bump{Bump: FinalBump}, "1.2.3"},
+		{"0.0.0", Bump{Bump: PatchBump}, "0.0.1"},
+
+		{"1.2.3-rc.4", Bump{Bump: MajorBump}, "2.0.0"},
+		{"1.2.3-rc.4", Bump{Bump: MinorBump}, "1.3.0"},
+		{"1.2.3-rc.4", Bump{Bump: PatchBump}, "1.2.4"},
+		{"1.2.3-rc.4", Bump{Bump: FinalBump}, "1.2.3"},
+
+		{"1.2.3", Bump{Pre: "rc"}, "1.2.3-rc.1"},
+		{"1.2.3-rc.1", Bump{Pre: "rc"}, "1.2.3-rc.2"},
+		{"1.2.3-rc.9", Bump{Pre: "rc"}, "1.2.3-rc.10"},
+		{"1.2.3-alpha.3", Bump{Pre: "rc"}, "1.2.3-rc.1"},
+		{"1.2.3-rc", Bump{Pre: "rc"}, "1.2.3-rc.1"},
+		{"1.2.3-rc.beta", Bump{Pre: "rc"}, "1.2.3-rc.1"},
+		{"1.2.3-rc.1.2", Bump{Pre: "rc"}, "1.2.3-rc.2"},
+		{"1.2.3-rcx.1", Bump{Pre: "rc"}, "1.2.3-rc.1"},
+
+		{"1.2.3", Bump{Bump: MajorBump, Pre: "rc"}, "2.0.0-rc.1"},
+		{"1.2.3", Bump{Bump: MinorBump, Pre: "rc"}, "1.3.0-rc.1"},
+		{"1.2.3", Bump{Bump: PatchBump, Pre: "rc"}, "1.2.4-rc.1"},
+		{"1.2.3-rc.2", Bump{Bump: MajorBump, Pre: "rc"}, "2.0.0-rc.1"},
+		{"1.2.3-rc.2", Bump{Bump: PatchBump, Pre: "rc"}, "1.2.4-rc.1"},
+		{"1.2.3-rc.2", Bump{Bump: FinalBump, Pre: "rc"}, "1.2.3-rc.1"},
+
+		{"1.2.3", Bump{Pre: "rc", PreWithoutVersion: true}, "1.2.3-rc"},
+		{"1.2.3-rc.2", Bump{Pre: "rc", PreWithoutVersion: true}, "1.2.3-rc"},
+		{"1.2.3", Bump{Bump: MinorBump, Pre: "beta", PreWithoutVersion: true}, "1.3.0-beta"},
+		{"1.2.3", Bump{Bump: MinorBump, PreWithoutVersion: true}, "1.3.0"},
+
+		{"1.2.3+build.1", Bump{Bump: PatchBump}, "1.2.4+build.1"},
+		{"1.2.3-rc.1+build.1", Bump{Pre: "rc"}, "1.2.3-rc.2+build.1"},
+	}
+	for _, test := range tests {
+		v, err := ParseSemVer(test.version)
+		assert.Nil(t, err)
+		bumped, err := test.bump.Apply(v)
+		assert.Nil(t, err)
+		assert.Equal(t, test.bumped, bumped.String(), "%s %+v", test.version, test.bump)
+	}
+}
+
+func Test_BumpApplyError(t *testing.T) {
+	v := SemVer{Major: 1}
+	_, err := Bump{Bump: "huge"}.Apply(v)
+	assert.EqualError(t, err, `invalid bump "huge", must be one of major, minor, patch, or final`)
+	_, err = Bump{Pre: "r.c"}.Apply(v)
+	assert.EqualError(t, err, `invalid pre-release name "r.c"`)
+	_, err = Bump{Pre: "1"}.Apply(v)
+	assert.EqualError(t, err, `invalid pre-release name "1"`)
+}
+
+func Test_BumpFromParams(t *testing.T) {
+	tests := []struct {
+		params Params
+		bump   Bump
+		err    string
+	}{
+		{Params{}, Bump{}, ""},
+		{Params{"bump": "minor", "pre": "rc"}, Bump{Bump: MinorBump, Pre: "rc"}, ""},
+		{Params{"pre": "rc", "pre_without_version": true}, Bump{Pre: "rc", PreWithoutVersion: true}, ""},
+		{Params{"bump": 1}, Bump{}, `params key "bump": expected string but got number`},
+		{Params{"bump": "final", "pre_without_version": "yes"}, Bump{Bump: FinalBump},
+			`params key "pre_without_version": expected bool but got string`},
+		{Params{"bump": "mayor"}, Bump{Bump: "mayor"},
+			`invalid bump "mayor", must be one of major, minor, patch, or final`},
+	}
+	for _, test := range tests {
+		bump, err := BumpFromParams(test.params)
+		if test.err == "" {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, test.err)
+		}
+		assert.Equal(t, test.bump, bump)
+	}
+}
+
+func Test_BumpApplyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "number")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("1.2.3-rc.1\n"), 0644))
+
+	version, err := Bump{Pre: "rc"}.ApplyFile(path, "")
+	assert.Nil(t, err)
+	assert.Equal(t, Version{"number": "1.2.3-rc.2"}, version)
+
+	version, err = Bump{Bump: FinalBump}.ApplyFile(path, "version")
+	assert.Nil(t, err)
+	assert.Equal(t, Version{"version": "1.2.3"}, version)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("latest"), 0644))
+	_, err = Bump{}.ApplyFile(path, "")
+	assert.EqualError(t, err, `invalid semantic version "latest": expected major.minor.patch`)
+
+	_, err = Bump{}.ApplyFile(filepath.Join(dir, "missing"), "")
+	assert.True(t, os.IsNotExist(err))
+}
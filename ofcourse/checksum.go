@@ -0,0 +1,241 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	// SHA256 is the name of the sha256 checksum algorithm.
+	SHA256 = "sha256"
+	// SHA512 is the name of the sha512 checksum algorithm.
+	SHA512 = "sha512"
+	// MD5 is the name of the md5 checksum algorithm.
+	MD5 = "md5"
+)
+
+var (
+	// bsdChecksumLine matches the output of `shasum --tag` and BSD `sha256`, e.g.
+	// "SHA256 (app.tgz) = <hex>".
+	bsdChecksumLine = regexp.MustCompile(`^([A-Za-z0-9]+) \((.+)\) = ([0-9A-Fa-f]+)$`)
+)
+
+// Checksum is the digest of an artifact computed with a named algorithm.
+type Checksum struct {
+	// Algorithm is one of SHA256, SHA512, or MD5.
+	Algorithm string
+	// Digest is the lowercase hex encoded digest.
+	Digest string
+}
+
+// ParseChecksum parses a checksum in the form "sha256:<hex>", or a bare hex digest,
+// in which case the algorithm is detected from its length.
+func ParseChecksum(s string) (Checksum, error) {
+	algorithm, digest := "", strings.TrimSpace(s)
+	if i := strings.Index(digest, ":"); i >= 0 {
+		algorithm, digest = strings.ToLower(digest[:i]), digest[i+1:]
+	}
+	return newChecksum(algorithm, digest)
+}
+
+func newChecksum(algorithm, digest string) (Checksum, error) {
+	digest = strings.ToLower(digest)
+	if _, err := hex.DecodeString(digest); err != nil {
+		return Checksum{}, fmt.Errorf("invalid checksum %q: not hex encoded", digest)
+	}
+	if algorithm == "" {
+		switch len(digest) {
+		case md5.Size * 2:
+			algorithm = MD5
+		case sha256.Size * 2:
+			algorithm = SHA256
+		case sha512.Size * 2:
+			algorithm = SHA512
+		default:
+			return Checksum{}, fmt.Errorf("invalid checksum %q: unknown length %d", digest, len(digest))
+		}
+	}
+	h, err := newHash(algorithm)
+	if err != nil {
+		return Checksum{}, err
+	}
+	if len(digest) != h.Size()*2 {
+		return Checksum{}, fmt.Errorf("invalid %s checksum %q: expected %d hex digits",
+			algorithm, digest, h.Size()*2)
+	}
+	return Checksum{Algorithm: algorithm, Digest: digest}, nil
+}
+
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case SHA256:
+		return sha256.New(), nil
+	case SHA512:
+		return sha512.New(), nil
+	case MD5:
+		return md5.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
+
+// String returns the checksum in the form "sha256:<hex>", suitable for a Version value.
+func (c Checksum) String() string {
+	return c.Algorithm + ":" + c.Digest
+}
+
+// NameVal returns the checksum as a Metadata entry named after its algorithm.
+func (c Checksum) NameVal() NameVal {
+	return NameVal{Name: c.Algorithm, Value: c.Digest}
+}
+
+// ChecksumMismatchError is returned when the digest of an artifact does not match
+// the expected checksum.
+type ChecksumMismatchError struct {
+	Name     string
+	Expected Checksum
+	Actual   Checksum
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch for %s: expected %s, got %s",
+		e.Expected.Algorithm, e.Name, e.Expected.Digest, e.Actual.Digest)
+}
+
+// VerifyingReader computes the digest of everything read through it, so an artifact
+// can be verified while it is streamed to its destination.
+type VerifyingReader struct {
+	r        io.Reader
+	hash     hash.Hash
+	name     string
+	expected Checksum
+}
+
+// NewVerifyingReader returns a VerifyingReader which reads from r and verifies that
+// the content matches the expected checksum. The name is used in error messages.
+func NewVerifyingReader(r io.Reader, name string, expected Checksum) (*VerifyingReader, error) {
+	h, err := newHash(expected.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyingReader{
+		r:        io.TeeReader(r, h),
+		hash:     h,
+		name:     name,
+		expected: expected,
+	}, nil
+}
+
+// Read reads from the underlying reader, adding the data to the digest.
+func (v *VerifyingReader) Read(p []byte) (int, error) {
+	return v.r.Read(p)
+}
+
+// Verify reads any remaining data from the underlying reader, then returns the actual
+// checksum, with a *ChecksumMismatchError if it does not match the expected checksum.
+func (v *VerifyingReader) Verify() (Checksum, error) {
+	if _, err := io.Copy(ioutil.Discard, v.r); err != nil {
+		return Checksum{}, err
+	}
+	actual := Checksum{
+		Algorithm: v.expected.Algorithm,
+		Digest:    hex.EncodeToString(v.hash.Sum(nil)),
+	}
+	if actual.Digest != v.expected.Digest {
+		return actual, &ChecksumMismatchError{Name: v.name, Expected: v.expected, Actual: actual}
+	}
+	return actual, nil
+}
+
+// Checksums maps file names to their checksums, as read from a checksum file.
+type Checksums map[string]Checksum
+
+// ParseChecksumFile parses a checksum file in the format written by `sha256sum` and
+// similar tools, as used for SHASUMS files, where each line is a hex digest followed by
+// the file name, or in the BSD format written by `shasum --tag`, e.g.
+// "SHA256 (app.tgz) = <hex>". Blank lines and lines starting with "#" are skipped. The
+// algorithm is detected from the length of each digest or from the BSD tag.
+func ParseChecksumFile(r io.Reader) (Checksums, error) {
+	checksums := Checksums{}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var algorithm, name, digest string
+		if matches := bsdChecksumLine.FindStringSubmatch(line); matches != nil {
+			algorithm, name, digest = strings.ToLower(matches[1]), matches[2], matches[3]
+		} else {
+			fields := strings.SplitN(line, " ", 2)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("invalid checksum file line %d: %q", lineNumber, line)
+			}
+			digest = fields[0]
+			name = strings.TrimPrefix(strings.TrimLeft(fields[1], " "), "*")
+		}
+
+		checksum, err := newChecksum(algorithm, digest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checksum file line %d: %s", lineNumber, err)
+		}
+		checksums[name] = checksum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// Find returns the checksum for a file name. If there is no exact match, the file is
+// matched by its base name, so that "dist/app.tgz" finds "app.tgz" and vice versa,
+// as long as only one file has that base name.
+func (c Checksums) Find(name string) (Checksum, bool) {
+	if checksum, ok := c[name]; ok {
+		return checksum, true
+	}
+	base := path.Base(name)
+	var found []Checksum
+	for n, checksum := range c {
+		if path.Base(n) == base {
+			found = append(found, checksum)
+		}
+	}
+	if len(found) != 1 {
+		return Checksum{}, false
+	}
+	return found[0], true
+}
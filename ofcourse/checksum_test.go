@@ -0,0 +1,140 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloMD5    = "5d41402abc4b2a76b9719d911017c592"
+	helloSHA512 = "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca7" +
+		"2323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"
+)
+
+func Test_ParseChecksum(t *testing.T) {
+	tests := []struct {
+		s        string
+		checksum Checksum
+		err      string
+	}{
+		{helloSHA256, Checksum{SHA256, helloSHA256}, ""},
+		{"sha256:" + strings.ToUpper(helloSHA256), Checksum{SHA256, helloSHA256}, ""},
+		{helloMD5, Checksum{MD5, helloMD5}, ""},
+		{"sha512:" + helloSHA512, Checksum{SHA512, helloSHA512}, ""},
+		{"sha256:" + helloMD5, Checksum{}, `invalid sha256 checksum "` + helloMD5 + `": expected 64 hex digits`},
+		{"sha1:" + helloMD5, Checksum{}, `unsupported checksum algorithm "sha1"`},
+		{"abc", Checksum{}, `invalid checksum "abc": not hex encoded`},
+		{"abcd", Checksum{}, `invalid checksum "abcd": unknown length 4`},
+	}
+	for _, test := range tests {
+		checksum, err := ParseChecksum(test.s)
+		if test.err == "" {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, test.err)
+		}
+		assert.Equal(t, test.checksum, checksum)
+	}
+
+	checksum := Checksum{SHA256, helloSHA256}
+	assert.Equal(t, "sha256:"+helloSHA256, checksum.String())
+	assert.Equal(t, NameVal{Name: "sha256", Value: helloSHA256}, checksum.NameVal())
+}
+
+func Test_VerifyingReader(t *testing.T) {
+	for _, expected := range []Checksum{{SHA256, helloSHA256}, {SHA512, helloSHA512}, {MD5, helloMD5}} {
+		v, err := NewVerifyingReader(strings.NewReader("hello"), "hello.txt", expected)
+		assert.Nil(t, err)
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(v)
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", buf.String())
+		actual, err := v.Verify()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	v, err := NewVerifyingReader(strings.NewReader("hello"), "hello.txt", Checksum{SHA256, helloSHA256})
+	assert.Nil(t, err)
+	_, err = v.Verify()
+	assert.Nil(t, err)
+
+	expected := Checksum{SHA256, strings.Repeat("0", 64)}
+	v, err = NewVerifyingReader(strings.NewReader("hello"), "hello.txt", expected)
+	assert.Nil(t, err)
+	_, err = ioutil.ReadAll(v)
+	assert.Nil(t, err)
+	actual, err := v.Verify()
+	assert.Equal(t, Checksum{SHA256, helloSHA256}, actual)
+	assert.Equal(t, &ChecksumMismatchError{Name: "hello.txt", Expected: expected, Actual: actual}, err)
+	assert.EqualError(t, err, "sha256 checksum mismatch for hello.txt: expected "+
+		strings.Repeat("0", 64)+", got "+helloSHA256)
+
+	_, err = NewVerifyingReader(strings.NewReader("hello"), "hello.txt", Checksum{})
+	assert.EqualError(t, err, `unsupported checksum algorithm ""`)
+}
+
+func Test_ParseChecksumFile(t *testing.T) {
+	file := `# SHASUMS for release 1.0.0
+` + helloSHA256 + `  app-linux.tgz
+` + strings.ToUpper(helloMD5) + ` *app-windows.zip
+
+SHA512 (app-darwin.tgz) = ` + helloSHA512 + `
+` + helloSHA256 + `  ./dist/app.deb
+`
+	checksums, err := ParseChecksumFile(strings.NewReader(file))
+	assert.Nil(t, err)
+	assert.Equal(t, Checksums{
+		"app-linux.tgz":   {SHA256, helloSHA256},
+		"app-windows.zip": {MD5, helloMD5},
+		"app-darwin.tgz":  {SHA512, helloSHA512},
+		"./dist/app.deb":  {SHA256, helloSHA256},
+	}, checksums)
+
+	tests := []struct {
+		name  string
+		found bool
+	}{
+		{"app-linux.tgz", true},
+		{"downloads/app-linux.tgz", true},
+		{"app.deb", true},
+		{"app-linux.zip", false},
+	}
+	for _, test := range tests {
+		_, found := checksums.Find(test.name)
+		assert.Equal(t, test.found, found, test.name)
+	}
+
+	checksums["other/app-linux.tgz"] = Checksum{MD5, helloMD5}
+	_, found := checksums.Find("downloads/app-linux.tgz")
+	assert.False(t, found)
+
+	_, err = ParseChecksumFile(strings.NewReader(helloSHA256 + "\n"))
+	assert.EqualError(t, err, `invalid checksum file line 1: "`+helloSHA256+`"`)
+	_, err = ParseChecksumFile(strings.NewReader("xyz  app.tgz\n"))
+	assert.EqualError(t, err, `invalid checksum file line 1: invalid checksum "xyz": not hex encoded`)
+}
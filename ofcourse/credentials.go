@@ -0,0 +1,289 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NetrcEntry is the login for one machine in a .netrc file.
+type NetrcEntry struct {
+	Machine  string
+	Login    string
+	Password string
+}
+
+// Credentials writes secrets to files in a private temporary directory, for use by
+// commands such as `git`, `ssh`, `docker`, and `curl`. The directory is only
+// accessible by the current user, and each file has 0600 permissions. It is
+// removed when the run ends, including when it is aborted, or by Remove.
+type Credentials struct {
+	// Dir is the private directory holding the files.
+	Dir string
+
+	logger     *Logger
+	mutex      sync.Mutex
+	sshKeys    []string
+	askPass    string
+	knownHosts string
+	netrc      []NetrcEntry
+	registries map[string]string
+	env        map[string]string
+}
+
+// NewCredentials creates a private temporary directory for credentials. Secrets
+// written to it are redacted from the logger's messages.
+func NewCredentials(logger *Logger) (*Credentials, error) {
+	dir, err := ioutil.TempDir("", "ofcourse-credentials")
+	if err != nil {
+		return nil, err
+	}
+	err = os.Chmod(dir, 0700)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	credentials := &Credentials{
+		Dir:        dir,
+		logger:     logger,
+		registries: map[string]string{},
+		env:        map[string]string{},
+	}
+	OnRunEnd(func() { credentials.Remove() })
+	return credentials, nil
+}
+
+// Remove deletes the directory and all of the credentials in it.
+func (c *Credentials) Remove() error {
+	return os.RemoveAll(c.Dir)
+}
+
+// SSHKey writes a PEM encoded SSH private key and returns its path. If passphrase
+// is not empty, it is given to `ssh` through an SSH_ASKPASS program. The
+// environment sets GIT_SSH_COMMAND to use the keys, along with any known hosts.
+func (c *Credentials) SSHKey(key, passphrase string) (string, error) {
+	if block, _ := pem.Decode([]byte(key)); block == nil {
+		return "", fmt.Errorf("SSH private key is not PEM encoded")
+	}
+	c.logger.Redact(strings.TrimSpace(key), passphrase)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	path := filepath.Join(c.Dir, fmt.Sprintf("ssh_key_%d", len(c.sshKeys)+1))
+	err := c.write(path, withNewline(key), 0600)
+	if err != nil {
+		return "", err
+	}
+	c.sshKeys = append(c.sshKeys, path)
+
+	if passphrase != "" {
+		err = c.writeAskPass(passphrase)
+		if err != nil {
+			return "", err
+		}
+	}
+	c.setSSHCommand()
+	return path, nil
+}
+
+// writeAskPass writes a program which prints the passphrase, used by ssh when
+// SSH_ASKPASS_REQUIRE is "force". All keys must share the same passphrase.
+func (c *Credentials) writeAskPass(passphrase string) error {
+	passphrasePath := filepath.Join(c.Dir, "ssh_passphrase")
+	err := c.write(passphrasePath, passphrase, 0600)
+	if err != nil {
+		return err
+	}
+	askPass := filepath.Join(c.Dir, "ssh_askpass")
+	script := fmt.Sprintf("#!/bin/sh\ncat %s\n", shellQuote(passphrasePath))
+	err = c.write(askPass, script, 0700)
+	if err != nil {
+		return err
+	}
+	c.askPass = askPass
+	c.env["SSH_ASKPASS"] = askPass
+	c.env["SSH_ASKPASS_REQUIRE"] = "force"
+	c.env["DISPLAY"] = "none"
+	return nil
+}
+
+// KnownHosts writes an ssh known_hosts file and returns its path. The environment
+// sets GIT_SSH_COMMAND to check host keys against it.
+func (c *Credentials) KnownHosts(knownHosts string) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	path := filepath.Join(c.Dir, "known_hosts")
+	err := c.write(path, withNewline(knownHosts), 0600)
+	if err != nil {
+		return "", err
+	}
+	c.knownHosts = path
+	c.setSSHCommand()
+	return path, nil
+}
+
+func (c *Credentials) setSSHCommand() {
+	command := []string{"ssh"}
+	for _, key := range c.sshKeys {
+		command = append(command, "-i", shellQuote(key))
+	}
+	if len(c.sshKeys) > 0 {
+		command = append(command, "-o", "IdentitiesOnly=yes")
+	}
+	if c.knownHosts != "" {
+		command = append(command, "-o", shellQuote("UserKnownHostsFile="+c.knownHosts),
+			"-o", "StrictHostKeyChecking=yes")
+	}
+	c.env["GIT_SSH_COMMAND"] = strings.Join(command, " ")
+}
+
+// PEM writes a bundle of PEM encoded certificates or keys to a file with the
+// given name and returns its path.
+func (c *Credentials) PEM(name, bundle string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid credentials file name %q", name)
+	}
+	if block, _ := pem.Decode([]byte(bundle)); block == nil {
+		return "", fmt.Errorf("%s is not PEM encoded", name)
+	}
+	if strings.Contains(bundle, "PRIVATE KEY") {
+		c.logger.Redact(strings.TrimSpace(bundle))
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	path := filepath.Join(c.Dir, name)
+	return path, c.write(path, withNewline(bundle), 0600)
+}
+
+// Netrc adds entries to a .netrc file and returns its path. The environment sets
+// NETRC to its path, which is used by `curl --netrc-file "$NETRC"`, Go, and other
+// tools. Later entries for a machine replace earlier ones.
+func (c *Credentials) Netrc(entries ...NetrcEntry) (string, error) {
+	for _, entry := range entries {
+		for _, value := range []string{entry.Machine, entry.Login, entry.Password} {
+			if value == "" || strings.ContainsAny(value, " \t\r\n\"") {
+				return "", fmt.Errorf("invalid .netrc entry for machine %q: "+
+					"values must be non-empty and contain no whitespace or quotes", entry.Machine)
+			}
+		}
+		c.logger.Redact(entry.Password)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, entry := range entries {
+		replaced := false
+		for i := range c.netrc {
+			if c.netrc[i].Machine == entry.Machine {
+				c.netrc[i] = entry
+				replaced = true
+			}
+		}
+		if !replaced {
+			c.netrc = append(c.netrc, entry)
+		}
+	}
+	var b strings.Builder
+	for _, entry := range c.netrc {
+		fmt.Fprintf(&b, "machine %s login %s password %s\n", entry.Machine, entry.Login, entry.Password)
+	}
+	path := filepath.Join(c.Dir, ".netrc")
+	err := c.write(path, b.String(), 0600)
+	if err != nil {
+		return "", err
+	}
+	c.env["NETRC"] = path
+	return path, nil
+}
+
+// RegistryAuth adds the login for a container registry, such as "ghcr.io", to a
+// Docker config.json file and returns its path. The environment sets DOCKER_CONFIG
+// to its directory, which is used by `docker`, `crane`, `skopeo`, and other tools.
+func (c *Credentials) RegistryAuth(registry, username, password string) (string, error) {
+	if registry == "" || username == "" {
+		return "", fmt.Errorf("registry and username are required for registry auth")
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	c.logger.Redact(password, auth)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.registries[registry] = auth
+	auths := map[string]map[string]string{}
+	for registry, auth := range c.registries {
+		auths[registry] = map[string]string{"auth": auth}
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"auths": auths}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(c.Dir, "docker")
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "config.json")
+	err = c.write(path, string(data)+"\n", 0600)
+	if err != nil {
+		return "", err
+	}
+	c.env["DOCKER_CONFIG"] = dir
+	return path, nil
+}
+
+// Env returns the environment variables for commands to use the credentials, as
+// "KEY=value" strings sorted by key, which may be appended to the Env of an
+// exec.Cmd.
+func (c *Credentials) Env() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	env := make([]string, 0, len(c.env))
+	for key, value := range c.env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func (c *Credentials) write(path, content string, perm os.FileMode) error {
+	return WriteFileAtomic(path, []byte(content), perm)
+}
+
+func withNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
+
+// shellQuote quotes s for a POSIX shell, as GIT_SSH_COMMAND is run by one.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
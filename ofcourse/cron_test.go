@@ -0,0 +1,90 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CronNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		expression string
+		from       time.Time
+		next       time.Time
+	}{
+		{"* * * * *", time.Date(2018, 10, 1, 12, 30, 15, 0, time.UTC),
+			time.Date(2018, 10, 1, 12, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2018, 10, 1, 12, 30, 0, 0, time.UTC),
+			time.Date(2018, 10, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2018, 10, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2018, 10, 1, 17, 0, 0, 0, time.UTC)},
+		{"30 2 * * mon-fri", time.Date(2018, 10, 5, 3, 0, 0, 0, time.UTC),
+			time.Date(2018, 10, 8, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2018, 10, 7, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2018, 10, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2018, 12, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2018, 12, 31, 23, 0, 0, 0, time.UTC),
+			time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+			time.Time{}},
+		{"30 2 * * *", time.Date(2018, 3, 11, 0, 0, 0, 0, newYork),
+			time.Date(2018, 3, 12, 2, 30, 0, 0, newYork)},
+		{"15 3 * * *", time.Date(2018, 3, 11, 1, 30, 0, 0, newYork),
+			time.Date(2018, 3, 11, 3, 15, 0, 0, newYork)},
+		{"0 9 * * *", time.Date(2018, 3, 11, 0, 0, 0, 0, newYork),
+			time.Date(2018, 3, 11, 9, 0, 0, 0, newYork)},
+	}
+	for _, test := range tests {
+		cron, err := ParseCron(test.expression)
+		assert.Nil(t, err, test.expression)
+		assert.True(t, test.next.Equal(cron.Next(test.from)), "%s from %s: %s",
+			test.expression, test.from, cron.Next(test.from))
+	}
+}
+
+func Test_ParseCronError(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"* * * *", `invalid cron expression "* * * *": expected 5 fields`},
+		{"60 * * * *", `invalid cron expression "60 * * * *": invalid minute "60"`},
+		{"* * 0 * *", `invalid cron expression "* * 0 * *": invalid day of month "0"`},
+		{"* * * foo *", `invalid cron expression "* * * foo *": invalid month "foo"`},
+		{"*/0 * * * *", `invalid cron expression "*/0 * * * *": invalid step in minute "*/0"`},
+		{"5-1 * * * *", `invalid cron expression "5-1 * * * *": invalid range in minute "5-1"`},
+		{"@often", `invalid cron expression "@often": expected 5 fields`},
+	}
+	for _, test := range tests {
+		_, err := ParseCron(test.expression)
+		assert.EqualError(t, err, test.err)
+	}
+}
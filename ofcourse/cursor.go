@@ -0,0 +1,117 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// CursorPolicy decides what VersionsSince returns when the cursor version, i.e. the
+// version passed to Check, is no longer among the candidate versions.
+type CursorPolicy int
+
+const (
+	// CursorLatest returns only the latest candidate when the cursor is missing.
+	CursorLatest CursorPolicy = iota
+	// CursorError returns ErrCursorNotFound when the cursor is missing.
+	CursorError
+)
+
+var (
+	// ErrCursorNotFound means the cursor version was not found among the candidates.
+	ErrCursorNotFound = errors.New("version not found")
+)
+
+// SinceOptions configures VersionsSince.
+type SinceOptions struct {
+	// MissingCursor is the policy for when the cursor is not among the candidates.
+	MissingCursor CursorPolicy
+	// Keys are the version keys compared to find the cursor. If empty, a candidate
+	// must be equal to the cursor.
+	Keys []string
+}
+
+// VersionsSince implements the usual semantics of Check, given candidate versions
+// ordered from oldest to newest and the version passed to Check. If the cursor is
+// nil, only the latest candidate is returned. Otherwise, the cursor and every
+// candidate newer than it are returned. If the cursor is not among the candidates,
+// the result depends on options.MissingCursor.
+func VersionsSince(candidates []Version, cursor Version, options SinceOptions) ([]Version, error) {
+	versions := []Version{}
+	if cursor == nil {
+		if len(candidates) > 0 {
+			versions = append(versions, candidates[len(candidates)-1])
+		}
+		return versions, nil
+	}
+
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if options.matches(candidates[i], cursor) {
+			return append(versions, candidates[i:]...), nil
+		}
+	}
+
+	switch options.MissingCursor {
+	case CursorError:
+		cursorJSON, _ := json.Marshal(cursor)
+		return nil, fmt.Errorf("%w: %s", ErrCursorNotFound, cursorJSON)
+	default:
+		if len(candidates) > 0 {
+			versions = append(versions, candidates[len(candidates)-1])
+		}
+		return versions, nil
+	}
+}
+
+// SortedVersionsSince is like VersionsSince, but first sorts a copy of the candidates
+// from oldest to newest using less, which reports whether a is older than b.
+func SortedVersionsSince(candidates []Version, less func(a, b Version) bool,
+	cursor Version, options SinceOptions) ([]Version, error) {
+	sorted := make([]Version, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	return VersionsSince(sorted, cursor, options)
+}
+
+func (o SinceOptions) matches(candidate, cursor Version) bool {
+	if len(o.Keys) == 0 {
+		if len(candidate) != len(cursor) {
+			return false
+		}
+		for k, v := range cursor {
+			if cv, ok := candidate[k]; !ok || cv != v {
+				return false
+			}
+		}
+		return true
+	}
+	for _, k := range o.Keys {
+		if candidate[k] != cursor[k] {
+			return false
+		}
+	}
+	return true
+}
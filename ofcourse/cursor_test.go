@@ -0,0 +1,143 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VersionsSince(t *testing.T) {
+	candidates := []Version{
+		{"ref": "a", "time": "1"},
+		{"ref": "b", "time": "2"},
+		{"ref": "c", "time": "3"},
+	}
+	tests := []struct {
+		name       string
+		candidates []Version
+		cursor     Version
+		options    SinceOptions
+		versions   []Version
+		err        error
+	}{
+		{
+			name:       "first run returns latest",
+			candidates: candidates,
+			cursor:     nil,
+			versions:   []Version{{"ref": "c", "time": "3"}},
+		},
+		{
+			name:       "first run without candidates",
+			candidates: nil,
+			cursor:     nil,
+			versions:   []Version{},
+		},
+		{
+			name:       "cursor and newer",
+			candidates: candidates,
+			cursor:     Version{"ref": "b", "time": "2"},
+			versions:   []Version{{"ref": "b", "time": "2"}, {"ref": "c", "time": "3"}},
+		},
+		{
+			name:       "cursor is latest",
+			candidates: candidates,
+			cursor:     Version{"ref": "c", "time": "3"},
+			versions:   []Version{{"ref": "c", "time": "3"}},
+		},
+		{
+			name:       "cursor is oldest",
+			candidates: candidates,
+			cursor:     Version{"ref": "a", "time": "1"},
+			versions:   candidates,
+		},
+		{
+			name:       "partial cursor does not match without keys",
+			candidates: candidates,
+			cursor:     Version{"ref": "a"},
+			versions:   []Version{{"ref": "c", "time": "3"}},
+		},
+		{
+			name:       "cursor matched by keys",
+			candidates: candidates,
+			cursor:     Version{"ref": "b"},
+			options:    SinceOptions{Keys: []string{"ref"}},
+			versions:   []Version{{"ref": "b", "time": "2"}, {"ref": "c", "time": "3"}},
+		},
+		{
+			name:       "missing cursor returns latest",
+			candidates: candidates,
+			cursor:     Version{"ref": "x", "time": "0"},
+			options:    SinceOptions{MissingCursor: CursorLatest},
+			versions:   []Version{{"ref": "c", "time": "3"}},
+		},
+		{
+			name:       "missing cursor without candidates returns nothing",
+			candidates: []Version{},
+			cursor:     Version{"ref": "x"},
+			options:    SinceOptions{MissingCursor: CursorLatest},
+			versions:   []Version{},
+		},
+		{
+			name:       "missing cursor is an error",
+			candidates: candidates,
+			cursor:     Version{"ref": "x", "time": "0"},
+			options:    SinceOptions{MissingCursor: CursorError},
+			err:        ErrCursorNotFound,
+		},
+		{
+			name:       "missing cursor without candidates is an error",
+			candidates: nil,
+			cursor:     Version{"ref": "x"},
+			options:    SinceOptions{MissingCursor: CursorError},
+			err:        ErrCursorNotFound,
+		},
+	}
+	for _, test := range tests {
+		versions, err := VersionsSince(test.candidates, test.cursor, test.options)
+		assert.Equal(t, test.versions, versions, test.name)
+		assert.True(t, errors.Is(err, test.err), test.name)
+	}
+}
+
+func Test_VersionsSinceError(t *testing.T) {
+	_, err := VersionsSince(nil, Version{"ref": "x"}, SinceOptions{MissingCursor: CursorError})
+	assert.EqualError(t, err, `version not found: {"ref":"x"}`)
+}
+
+func Test_SortedVersionsSince(t *testing.T) {
+	candidates := []Version{
+		{"build": "10"},
+		{"build": "2"},
+		{"build": "9"},
+	}
+	less := func(a, b Version) bool {
+		i, _ := strconv.Atoi(a["build"])
+		j, _ := strconv.Atoi(b["build"])
+		return i < j
+	}
+	versions, err := SortedVersionsSince(candidates, less, Version{"build": "9"}, SinceOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []Version{{"build": "9"}, {"build": "10"}}, versions)
+	assert.Equal(t, Version{"build": "10"}, candidates[0])
+}
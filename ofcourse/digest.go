@@ -0,0 +1,177 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultDigestVersionKey is the version key used by DigestPath if none is given.
+	DefaultDigestVersionKey = "digest"
+)
+
+// DigestOptions configures DigestPath.
+type DigestOptions struct {
+	// IncludeModes includes the permission bits of each file in the digest, so
+	// that changing only a file's mode changes the digest.
+	IncludeModes bool
+	// Exclude contains patterns for files and directories to leave out, as accepted
+	// by path.Match. A pattern is matched against both the slash separated path
+	// relative to the digested path and the base name, so "*.log" excludes log files
+	// in every directory, while "cache/*" only excludes files in the top level cache.
+	Exclude []string
+	// Key is the version key for the digest, defaulting to DefaultDigestVersionKey.
+	Key string
+}
+
+// DigestPath computes a deterministic sha256 digest of a file or directory at p,
+// which is relative to inputDirectory and may not be outside of it. Directories are
+// walked in lexical order, and the digest covers the relative path, type, and
+// contents of every entry, so identical content always produces the same digest.
+// Symbolic links are not followed, and their targets are digested instead. It returns
+// a Version with the digest in the form "sha256:<hex>", and Metadata with the number
+// of files and their total size.
+func DigestPath(inputDirectory, p string, options DigestOptions) (Version, Metadata, error) {
+	if err := validatePatterns(options.Exclude); err != nil {
+		return nil, nil, err
+	}
+	root, err := pathWithin(inputDirectory, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digest := sha256.New()
+	var fileCount, totalSize int64
+	err = filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && matchesAny(options.Exclude, rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		mode := ""
+		if options.IncludeModes {
+			mode = fmt.Sprintf("%04o", info.Mode().Perm())
+		}
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(file)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(digest, "l\x00%s\x00%s\x00%s\n", rel, mode, target)
+		case info.IsDir():
+			fmt.Fprintf(digest, "d\x00%s\x00%s\n", rel, mode)
+		case info.Mode().IsRegular():
+			contentDigest, err := fileDigest(file)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(digest, "f\x00%s\x00%s\x00%s\n", rel, mode, contentDigest)
+			fileCount++
+			totalSize += info.Size()
+		default:
+			return fmt.Errorf("cannot digest %s with mode %s", file, info.Mode())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := options.Key
+	if key == "" {
+		key = DefaultDigestVersionKey
+	}
+	version := Version{key: "sha256:" + hex.EncodeToString(digest.Sum(nil))}
+	metadata := NewMetadataBuilder().
+		Int("file_count", fileCount).
+		Bytes("total_size", totalSize).
+		Build()
+	return version, metadata, nil
+}
+
+func fileDigest(file string) (string, error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+	digest := sha256.New()
+	if _, err := io.Copy(digest, fd); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// pathWithin joins p to dir, returning an error if the result is outside of dir.
+func pathWithin(dir, p string) (string, error) {
+	joined := filepath.Join(dir, p)
+	rel, err := filepath.Rel(dir, joined)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside of %s", p, dir)
+	}
+	return joined, nil
+}
+
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchesAny reports whether a slash separated relative path or its base name
+// matches any of the patterns.
+func matchesAny(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, rel); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
+}
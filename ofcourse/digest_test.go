@@ -0,0 +1,113 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0644))
+	}
+}
+
+func Test_DigestPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a/index.html":      "<html></html>",
+		"a/css/site.css":    "body {}",
+		"a/build.log":       "building",
+		"a/cache/entry":     "cached",
+		"b/css/site.css":    "body {}",
+		"b/index.html":      "<html></html>",
+		"c/index.html":      "<html>changed</html>",
+		"c/css/site.css":    "body {}",
+		"single/index.html": "<html></html>",
+	}
+	writeTestFiles(t, dir, files)
+
+	options := DigestOptions{Exclude: []string{"*.log", "cache"}}
+	a, metadata, err := DigestPath(dir, "a", options)
+	assert.Nil(t, err)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", a["digest"])
+	assert.Equal(t, Metadata{
+		{Name: "file_count", Value: "2"},
+		{Name: "total_size", Value: "20 B"},
+	}, metadata)
+
+	b, _, err := DigestPath(dir, "b", options)
+	assert.Nil(t, err)
+	assert.Equal(t, a, b)
+
+	c, _, err := DigestPath(dir, "c", options)
+	assert.Nil(t, err)
+	assert.NotEqual(t, a, c)
+
+	all, metadata, err := DigestPath(dir, "a", DigestOptions{Key: "sha"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, a["digest"], all["sha"])
+	assert.Equal(t, "4", metadata[0].Value)
+
+	single, metadata, err := DigestPath(dir, "single/index.html", DigestOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", metadata[0].Value)
+	assert.NotEqual(t, a["digest"], single["digest"])
+
+	assert.Nil(t, os.Chmod(filepath.Join(dir, "b/index.html"), 0755))
+	b, _, err = DigestPath(dir, "b", options)
+	assert.Nil(t, err)
+	assert.Equal(t, a, b)
+	options.IncludeModes = true
+	a, _, _ = DigestPath(dir, "a", options)
+	b, _, _ = DigestPath(dir, "b", options)
+	assert.NotEqual(t, a, b)
+
+	assert.Nil(t, os.Symlink("index.html", filepath.Join(dir, "c/link")))
+	before := c
+	c, _, err = DigestPath(dir, "c", DigestOptions{Exclude: []string{"*.log", "cache"}})
+	assert.Nil(t, err)
+	assert.NotEqual(t, before, c)
+}
+
+func Test_DigestPathError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	_, _, err = DigestPath(dir, "../etc", DigestOptions{})
+	assert.EqualError(t, err, `path "../etc" is outside of `+dir)
+
+	_, _, err = DigestPath(dir, "missing", DigestOptions{})
+	assert.True(t, os.IsNotExist(err))
+
+	_, _, err = DigestPath(dir, ".", DigestOptions{Exclude: []string{"[a-"}})
+	assert.EqualError(t, err, `invalid pattern "[a-": syntax error in pattern`)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import "fmt"
+
+// DryRunKey is the standard `put` params and source key which asks the resource
+// not to make any remote changes. It is only handled by ofcourse for resources
+// which set Config.DryRun.
+const DryRunKey = "dry_run"
+
+// DryRun returns true if the `put` is a dry run, in which case Out should validate
+// its inputs and return the version and metadata it would have produced, without
+// making any remote changes. The `out` dispatcher sets DryRunKey in the params if
+// either the params or the source ask for a dry run, with the params taking
+// precedence.
+func (p Params) DryRun() bool {
+	dryRun, _ := p[DryRunKey].(bool)
+	return dryRun
+}
+
+// dryRunOf returns whether the params or source ask for a dry run.
+func dryRunOf(source Source, params Params) (bool, error) {
+	if _, ok := params[DryRunKey]; ok {
+		return params.Bool(DryRunKey)
+	}
+	if _, ok := source[DryRunKey]; ok {
+		return source.Bool(DryRunKey)
+	}
+	return false, nil
+}
+
+// prepareDryRun sets DryRunKey in params if a dry run is requested. It fails if a
+// resource which does not set Config.DryRun is asked for a dry run, rather than let
+// the `put` make changes. Otherwise such a resource receives its params unchanged.
+func prepareDryRun(config Config, source Source, params Params) (bool, error) {
+	if !config.DryRun {
+		if requested, _ := dryRunOf(source, params); requested {
+			return false, fmt.Errorf("%s is not supported by this resource", DryRunKey)
+		}
+		return false, nil
+	}
+	dryRun, err := dryRunOf(source, params)
+	if err != nil {
+		return false, err
+	}
+	params[DryRunKey] = dryRun
+	return dryRun, nil
+}
+
+// tagDryRun adds a `dry_run: true` entry to metadata.
+func tagDryRun(metadata Metadata) Metadata {
+	for _, nameVal := range metadata {
+		if nameVal.Name == DryRunKey {
+			return metadata
+		}
+	}
+	return append(metadata, NameVal{Name: DryRunKey, Value: "true"})
+}
@@ -0,0 +1,144 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dryRunResource struct {
+	emptyResource
+	dryRun   bool
+	params   Params
+	metadata Metadata
+}
+
+func (r *dryRunResource) Config() Config {
+	return Config{DryRun: r.dryRun}
+}
+
+func (r *dryRunResource) Out(inDir string, source Source, params Params,
+	env Environment, logger *Logger) (Version, Metadata, error) {
+	r.params = params
+	if params.DryRun() {
+		return Version{"number": "1.2.3"}, r.metadata, nil
+	}
+	return Version{"number": "1.2.3"}, Metadata{{Name: "pushed", Value: "true"}}, nil
+}
+
+func Test_outDryRun(t *testing.T) {
+	tests := []struct {
+		supported bool
+		input     string
+		params    Params
+		metadata  Metadata
+		err       string
+	}{
+		{
+			true,
+			`{"source": {"log_level": "silent"}, "params": {}}`,
+			Params{"dry_run": false},
+			Metadata{{Name: "pushed", Value: "true"}},
+			"",
+		},
+		{
+			true,
+			`{"source": {"log_level": "silent"}, "params": {"dry_run": true}}`,
+			Params{"dry_run": true},
+			Metadata{{Name: "tag", Value: "v1.2.3"}, {Name: "dry_run", Value: "true"}},
+			"",
+		},
+		{
+			true,
+			`{"source": {"log_level": "silent", "dry_run": true}, "params": {}}`,
+			Params{"dry_run": true},
+			Metadata{{Name: "tag", Value: "v1.2.3"}, {Name: "dry_run", Value: "true"}},
+			"",
+		},
+		{
+			true,
+			`{"source": {"log_level": "silent", "dry_run": true}, "params": {"dry_run": false}}`,
+			Params{"dry_run": false},
+			Metadata{{Name: "pushed", Value: "true"}},
+			"",
+		},
+		{
+			true,
+			`{"source": {"log_level": "silent"}, "params": {"dry_run": "yes"}}`,
+			nil,
+			nil,
+			`params key "dry_run": expected bool but got string`,
+		},
+		{
+			false,
+			`{"source": {"log_level": "silent"}, "params": {"dry_run": true}}`,
+			nil,
+			nil,
+			"dry_run is not supported by this resource",
+		},
+		{
+			false,
+			`{"source": {"log_level": "silent", "dry_run": true}, "params": {}}`,
+			nil,
+			nil,
+			"dry_run is not supported by this resource",
+		},
+		{
+			false,
+			`{"source": {"log_level": "silent"}, "params": {"dry_run": false}}`,
+			Params{"dry_run": false},
+			Metadata{{Name: "pushed", Value: "true"}},
+			"",
+		},
+		{
+			false,
+			`{"source": {"log_level": "silent"}, "params": {"dry_run": "yes"}}`,
+			Params{"dry_run": "yes"},
+			Metadata{{Name: "pushed", Value: "true"}},
+			"",
+		},
+	}
+	for _, test := range tests {
+		resource := &dryRunResource{
+			dryRun:   test.supported,
+			metadata: Metadata{{Name: "tag", Value: "v1.2.3"}},
+		}
+		output, err := out(resource, "/tmp", []byte(test.input))
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		assert.Nil(t, err)
+		var result inOutOutput
+		assert.Nil(t, json.Unmarshal(output, &result))
+		assert.Equal(t, test.metadata, result.Metadata, test.input)
+		assert.Equal(t, test.params, resource.params, test.input)
+	}
+}
+
+func Test_tagDryRun(t *testing.T) {
+	assert.Equal(t, Metadata{{Name: "dry_run", Value: "true"}}, tagDryRun(nil))
+	metadata := Metadata{{Name: "dry_run", Value: "yes"}}
+	assert.Equal(t, metadata, tagDryRun(metadata))
+}
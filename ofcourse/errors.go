@@ -0,0 +1,127 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"errors"
+	"io"
+	"net"
+)
+
+// ErrorCategory classifies errors so that helpers such as Retry can decide how
+// to handle them.
+type ErrorCategory int
+
+const (
+	// UnknownError is the category of errors which have not been classified.
+	UnknownError ErrorCategory = iota
+	// TransientError is the category of errors which may succeed if retried,
+	// such as a connection reset or a 503 response.
+	TransientError
+	// PermanentError is the category of errors which will not succeed if
+	// retried, such as invalid credentials.
+	PermanentError
+)
+
+func (c ErrorCategory) String() string {
+	switch c {
+	case TransientError:
+		return "transient"
+	case PermanentError:
+		return "permanent"
+	}
+	return "unknown"
+}
+
+// categorizedError is an error with an explicit category.
+type categorizedError struct {
+	err      error
+	category ErrorCategory
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() error {
+	return e.err
+}
+
+func (e *categorizedError) Category() ErrorCategory {
+	return e.category
+}
+
+// WithCategory returns err marked with category, which overrides any category
+// of the errors it wraps.
+func WithCategory(err error, category ErrorCategory) error {
+	if err == nil {
+		return nil
+	}
+	return &categorizedError{err: err, category: category}
+}
+
+// Transient marks err as a TransientError.
+func Transient(err error) error {
+	return WithCategory(err, TransientError)
+}
+
+// Permanent marks err as a PermanentError.
+func Permanent(err error) error {
+	return WithCategory(err, PermanentError)
+}
+
+// CategoryOf returns the category of err. The first error in the chain with a
+// `Category() ErrorCategory` method, such as one returned by WithCategory, decides
+// the category. Otherwise, network timeouts, failed connections, and unexpected
+// EOFs are transient, and other errors are unknown.
+func CategoryOf(err error) ErrorCategory {
+	if err == nil {
+		return UnknownError
+	}
+	var categorized interface{ Category() ErrorCategory }
+	if errors.As(err, &categorized) {
+		return categorized.Category()
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return TransientError
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout || dnsErr.IsTemporary {
+			return TransientError
+		}
+		return UnknownError
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return TransientError
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return TransientError
+	}
+	return UnknownError
+}
+
+// IsTransient returns true if the category of err is TransientError.
+func IsTransient(err error) bool {
+	return CategoryOf(err) == TransientError
+}
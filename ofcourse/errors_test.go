@@ -0,0 +1,63 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CategoryOf(t *testing.T) {
+	errReset := errors.New("connection reset by peer")
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: errReset}
+	tests := []struct {
+		err      error
+		category ErrorCategory
+	}{
+		{nil, UnknownError},
+		{errors.New("invalid"), UnknownError},
+		{Transient(errors.New("busy")), TransientError},
+		{fmt.Errorf("fetching: %w", Permanent(reset)), PermanentError},
+		{reset, TransientError},
+		{fmt.Errorf("fetching: %w", io.ErrUnexpectedEOF), TransientError},
+		{&net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, UnknownError},
+		{&net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true}, TransientError},
+		{context.Canceled, UnknownError},
+		{&HTTPStatusError{StatusCode: 503, Status: "503 Service Unavailable"}, TransientError},
+		{&HTTPStatusError{StatusCode: 404, Status: "404 Not Found"}, PermanentError},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.category, CategoryOf(test.err), fmt.Sprint(test.err))
+		assert.Equal(t, test.category == TransientError, IsTransient(test.err))
+	}
+
+	assert.Nil(t, WithCategory(nil, PermanentError))
+	err := Transient(reset)
+	assert.EqualError(t, err, reset.Error())
+	assert.True(t, errors.Is(err, errReset))
+	assert.Equal(t, "transient", TransientError.String())
+}
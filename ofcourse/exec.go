@@ -0,0 +1,235 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTailLines is the number of lines of output kept by a Command for
+	// error messages.
+	DefaultTailLines = 20
+	// waitDelay is how long a Command waits for its output to be closed after it
+	// exits or is cancelled, in case it leaves behind children which hold it open.
+	waitDelay = 2 * time.Second
+	// maxLineLength is the longest line a Command buffers before logging it.
+	maxLineLength = 64 * 1024
+)
+
+// Command runs an external program, logging each line of its stdout and stderr
+// through a Logger instead of writing to the process's stdout, which would corrupt
+// the JSON output expected by Concourse.
+type Command struct {
+	Name string
+	Args []string
+	// Dir is the working directory, defaulting to the current directory.
+	Dir string
+	// Env is the environment, as with exec.Cmd, defaulting to the current
+	// environment if nil.
+	Env []string
+	// Stdin is the program's input, defaulting to none.
+	Stdin io.Reader
+	// Stdout, if set, receives the program's stdout instead of the logger,
+	// such as to capture output which is parsed.
+	Stdout io.Writer
+	// Context bounds the program's run, defaulting to RunContext().
+	Context context.Context
+	// Timeout, if greater than zero, limits how long the program runs.
+	Timeout time.Duration
+	// Logger logs the program's output at info level.
+	Logger *Logger
+	// Prefix is prepended to each line of output, defaulting to "[name] ".
+	Prefix string
+	// Secrets are redacted from the arguments and output, in addition to the
+	// secrets already known to the logger.
+	Secrets []string
+	// TailLines is the number of lines of output kept for Result and
+	// ExitError, defaulting to DefaultTailLines.
+	TailLines int
+}
+
+// Result describes a program which ran to completion.
+type Result struct {
+	// ExitCode is the program's exit status, or -1 if it was killed by a signal.
+	ExitCode int
+	// Signal is the signal which killed the program, if any.
+	Signal   string
+	Duration time.Duration
+	// TimedOut is true if the program was killed because Timeout passed.
+	TimedOut bool
+	// Tail is the last lines of the program's output, redacted.
+	Tail []string
+}
+
+// ExitError is returned when a program exits with a nonzero status or is killed.
+type ExitError struct {
+	// Command is the command line, with secrets redacted.
+	Command string
+	Result
+}
+
+func (e *ExitError) Error() string {
+	var reason string
+	switch {
+	case e.TimedOut:
+		reason = "timed out"
+	case e.Signal != "":
+		reason = "was killed by signal " + e.Signal
+	default:
+		reason = fmt.Sprintf("exited with status %d", e.ExitCode)
+	}
+	message := fmt.Sprintf("command %q %s after %s", e.Command, reason, e.Duration.Round(time.Millisecond))
+	if len(e.Tail) > 0 {
+		message += ":\n" + strings.Join(e.Tail, "\n")
+	}
+	return message
+}
+
+// NewCommand returns a Command which runs name with args, logging to logger.
+func NewCommand(logger *Logger, name string, args ...string) *Command {
+	return &Command{Name: name, Args: args, Logger: logger}
+}
+
+// Run runs the program and waits for it to finish. If it does not exit with a zero
+// status, the error is an ExitError. If it cannot be started, the error is from
+// exec.Cmd.
+func (c *Command) Run() (*Result, error) {
+	c.Logger.Redact(c.Secrets...)
+	ctx := c.Context
+	if ctx == nil {
+		ctx = RunContext()
+	}
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	prefix := c.Prefix
+	if prefix == "" {
+		prefix = fmt.Sprintf("[%s] ", c.Name)
+	}
+	logger := c.Logger.WithPrefix(prefix)
+	tailLines := c.TailLines
+	if tailLines <= 0 {
+		tailLines = DefaultTailLines
+	}
+	commandLine := c.Logger.RedactString(strings.Join(append([]string{c.Name}, c.Args...), " "))
+
+	tail := &outputTail{max: tailLines, logger: logger}
+	stdout := &lineWriter{emit: tail.add}
+	stderr := &lineWriter{emit: tail.add}
+	cmd := exec.CommandContext(ctx, c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = c.Env
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = stdout
+	if c.Stdout != nil {
+		cmd.Stdout = c.Stdout
+	}
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+
+	logger.Debugf("running %s", commandLine)
+	start := time.Now()
+	err := cmd.Run()
+	stdout.flush()
+	stderr.flush()
+	result := &Result{Duration: time.Since(start), Tail: tail.lines()}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+		result.Signal = exitSignal(cmd.ProcessState)
+	}
+	if err == nil {
+		return result, nil
+	}
+	if errors.Is(err, exec.ErrWaitDelay) && cmd.ProcessState.Success() {
+		logger.Debugf("output of %s was left open after it exited", commandLine)
+		return result, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, err
+	}
+	result.TimedOut = c.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded)
+	return result, &ExitError{Command: commandLine, Result: *result}
+}
+
+// outputTail logs lines of output and keeps the last of them.
+type outputTail struct {
+	mutex  sync.Mutex
+	max    int
+	logger *Logger
+	tail   []string
+}
+
+func (t *outputTail) add(line string) {
+	t.logger.Infof("%s", line)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.tail = append(t.tail, t.logger.RedactString(line))
+	if len(t.tail) > t.max {
+		t.tail = t.tail[len(t.tail)-t.max:]
+	}
+}
+
+func (t *outputTail) lines() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]string(nil), t.tail...)
+}
+
+// lineWriter calls emit with each line written to it. Carriage returns, as used by
+// progress bars, also end lines, since the Concourse UI does not redraw them.
+type lineWriter struct {
+	emit    func(string)
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b == '\n' || b == '\r' {
+			w.flush()
+			continue
+		}
+		w.partial = append(w.partial, b)
+		if len(w.partial) >= maxLineLength {
+			w.flush()
+		}
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) flush() {
+	if len(w.partial) == 0 {
+		return
+	}
+	w.emit(string(w.partial))
+	w.partial = w.partial[:0]
+}
@@ -0,0 +1,112 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CommandRun(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{Level: infoLevel, Output: &buf}
+	command := NewCommand(logger, "sh", "-c", `echo "token is $1"; echo "to stderr" >&2; printf 'partial'`,
+		"sh", "t0ken")
+	command.Secrets = []string{"t0ken"}
+	result, err := command.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, "", result.Signal)
+	assert.False(t, result.TimedOut)
+	assert.Len(t, result.Tail, 3)
+	assert.Contains(t, result.Tail, "token is ((redacted))")
+	assert.Contains(t, result.Tail, "partial")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines, "\033[1;32m[sh] token is ((redacted))\033[0m")
+	assert.Contains(t, lines, "\033[1;32m[sh] to stderr\033[0m")
+	assert.NotContains(t, buf.String(), "t0ken")
+}
+
+func Test_CommandStdout(t *testing.T) {
+	var buf, stdout bytes.Buffer
+	command := NewCommand(&Logger{Level: infoLevel, Output: &buf}, "sh", "-c", "echo abc123; echo warning >&2")
+	command.Stdout = &stdout
+	command.Prefix = "git: "
+	command.Stdin = strings.NewReader("")
+	_, err := command.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, "abc123\n", stdout.String())
+	assert.Equal(t, "\033[1;32mgit: warning\033[0m\n", buf.String())
+}
+
+func Test_CommandExitError(t *testing.T) {
+	logger := NewLogger(SilentLevel)
+	command := NewCommand(logger, "sh", "-c", `for i in 1 2 3 4; do echo "line $i"; done; exit 3`,
+		"sh", "--password=hunter2")
+	command.Secrets = []string{"hunter2"}
+	command.TailLines = 2
+	result, err := command.Run()
+	var exitErr *ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, []string{"line 3", "line 4"}, exitErr.Tail)
+	assert.Regexp(t, `^command "sh -c .* sh --password=\(\(redacted\)\)" exited with status 3 after \S+:\n`+
+		`line 3\nline 4$`, err.Error())
+
+	_, err = NewCommand(logger, "ofcourse-no-such-command").Run()
+	assert.NotNil(t, err)
+	assert.False(t, errors.As(err, &exitErr))
+}
+
+func Test_CommandTimeout(t *testing.T) {
+	command := NewCommand(NewLogger(SilentLevel), "sleep", "10")
+	command.Timeout = 50 * time.Millisecond
+	start := time.Now()
+	result, err := command.Run()
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.True(t, result.TimedOut)
+	assert.Equal(t, -1, result.ExitCode)
+	assert.Equal(t, "killed", result.Signal)
+	assert.Regexp(t, `^command "sleep 10" timed out after \S+$`, err.Error())
+
+	command = NewCommand(NewLogger(SilentLevel), "sh", "-c", "sleep 10 & sleep 10")
+	command.Timeout = 50 * time.Millisecond
+	start = time.Now()
+	result, err = command.Run()
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.True(t, result.TimedOut)
+	assert.NotNil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	command = NewCommand(NewLogger(SilentLevel), "sleep", "10")
+	command.Context = ctx
+	_, err = command.Run()
+	assert.NotNil(t, err)
+}
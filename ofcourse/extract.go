@@ -0,0 +1,195 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// VersionFormat is the format of a version extracted from a name, which decides how
+// it is parsed and ordered.
+type VersionFormat int
+
+const (
+	// SemVerFormat parses a semantic version such as "1.4.2-rc.1".
+	SemVerFormat VersionFormat = iota
+	// DateFormat parses a date or time using the extractor's Layout.
+	DateFormat
+	// NumberFormat parses a decimal number such as "42" or "1.5".
+	NumberFormat
+)
+
+const (
+	// DefaultDateLayout is used by an Extractor with DateFormat if Layout is empty.
+	DefaultDateLayout = "2006-01-02"
+)
+
+var (
+	// ErrNoMatch means a name does not match the extractor's regular expression.
+	ErrNoMatch = errors.New("name does not match version pattern")
+)
+
+// Extractor extracts versions from names, such as file names in a bucket, and
+// orders them. The version is taken from the capture group named "version" in the
+// regular expression, or from the first capture group if there is none by that name.
+type Extractor struct {
+	// Regexp is the regular expression used to find the version in a name.
+	Regexp *regexp.Regexp
+	// Format is the format of the extracted version.
+	Format VersionFormat
+	// Layout is the time layout of a version in DateFormat.
+	Layout string
+	// Constraint optionally limits semantic versions to those that satisfy it.
+	Constraint *Constraint
+}
+
+// ExtractedVersion is a version extracted from a name.
+type ExtractedVersion struct {
+	// Name is the name the version was extracted from.
+	Name string
+	// Version is the text of the version within the name.
+	Version string
+	// SemVer is the parsed version in SemVerFormat.
+	SemVer SemVer
+	// Time is the parsed version in DateFormat.
+	Time time.Time
+	// Number is the parsed version in NumberFormat.
+	Number *big.Rat
+
+	format VersionFormat
+}
+
+// NewExtractor returns an Extractor for a regular expression with a capture group.
+func NewExtractor(pattern string, format VersionFormat) (*Extractor, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("regular expression %q has no capture group", pattern)
+	}
+	return &Extractor{Regexp: re, Format: format}, nil
+}
+
+// Extract extracts and parses the version from a name. It returns an error wrapping
+// ErrNoMatch if the name does not match, or an error if the version cannot be parsed.
+// The constraint is not checked.
+func (e *Extractor) Extract(name string) (ExtractedVersion, error) {
+	extracted := ExtractedVersion{Name: name, format: e.Format}
+
+	matches := e.Regexp.FindStringSubmatch(name)
+	if matches == nil || len(matches) < 2 {
+		return extracted, fmt.Errorf("%w: %q", ErrNoMatch, name)
+	}
+	group := 1
+	if i := e.Regexp.SubexpIndex("version"); i > 0 {
+		group = i
+	}
+	extracted.Version = matches[group]
+
+	switch e.Format {
+	case SemVerFormat:
+		v, err := ParseSemVer(extracted.Version)
+		if err != nil {
+			return extracted, err
+		}
+		extracted.SemVer = v
+	case DateFormat:
+		layout := e.Layout
+		if layout == "" {
+			layout = DefaultDateLayout
+		}
+		t, err := time.Parse(layout, extracted.Version)
+		if err != nil {
+			return extracted, err
+		}
+		extracted.Time = t
+	case NumberFormat:
+		n, ok := new(big.Rat).SetString(extracted.Version)
+		if !ok {
+			return extracted, fmt.Errorf("invalid number %q", extracted.Version)
+		}
+		extracted.Number = n
+	default:
+		return extracted, fmt.Errorf("unknown version format %d", e.Format)
+	}
+	return extracted, nil
+}
+
+// ExtractAll extracts versions from names, skipping names that do not match or do
+// not satisfy the constraint, and returns them ordered from oldest to newest.
+func (e *Extractor) ExtractAll(names []string) []ExtractedVersion {
+	extracted := []ExtractedVersion{}
+	for _, name := range names {
+		v, err := e.Extract(name)
+		if err != nil {
+			continue
+		}
+		if e.Format == SemVerFormat && e.Constraint != nil && !e.Constraint.Check(v.SemVer) {
+			continue
+		}
+		extracted = append(extracted, v)
+	}
+	sort.SliceStable(extracted, func(i, j int) bool {
+		c := extracted[i].Compare(extracted[j])
+		if c == 0 {
+			return extracted[i].Name < extracted[j].Name
+		}
+		return c < 0
+	})
+	return extracted
+}
+
+// Versions is like ExtractAll, but returns a Version for each name with the
+// name as the value of key. The result may be passed to VersionsSince.
+func (e *Extractor) Versions(names []string, key string) []Version {
+	extracted := e.ExtractAll(names)
+	versions := make([]Version, len(extracted))
+	for i, v := range extracted {
+		versions[i] = Version{key: v.Name}
+	}
+	return versions
+}
+
+// Compare returns -1, 0, or 1 if v is older, the same age, or newer than o. Both
+// must have been extracted with the same format.
+func (v ExtractedVersion) Compare(o ExtractedVersion) int {
+	switch v.format {
+	case DateFormat:
+		switch {
+		case v.Time.Before(o.Time):
+			return -1
+		case v.Time.After(o.Time):
+			return 1
+		default:
+			return 0
+		}
+	case NumberFormat:
+		return v.Number.Cmp(o.Number)
+	default:
+		return v.SemVer.Compare(o.SemVer)
+	}
+}
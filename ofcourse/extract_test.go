@@ -0,0 +1,100 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractorSemVer(t *testing.T) {
+	e, err := NewExtractor(`^app-(?P<version>.*)\.tgz$`, SemVerFormat)
+	assert.Nil(t, err)
+
+	names := []string{
+		"app-1.10.0.tgz",
+		"app-1.2.0.tgz",
+		"app-2.0.0-rc.1.tgz",
+		"app-2.0.0.tgz",
+		"app-1.2.0-rc.1.tgz",
+		"app-latest.tgz",
+		"README.md",
+	}
+	assert.Equal(t, []Version{
+		{"path": "app-1.2.0-rc.1.tgz"},
+		{"path": "app-1.2.0.tgz"},
+		{"path": "app-1.10.0.tgz"},
+		{"path": "app-2.0.0-rc.1.tgz"},
+		{"path": "app-2.0.0.tgz"},
+	}, e.Versions(names, "path"))
+
+	e.Constraint, err = ParseConstraint("~1.2 || >=1.10.0 <2")
+	assert.Nil(t, err)
+	assert.Equal(t, []Version{
+		{"path": "app-1.2.0.tgz"},
+		{"path": "app-1.10.0.tgz"},
+	}, e.Versions(names, "path"))
+
+	_, err = e.Extract("README.md")
+	assert.True(t, errors.Is(err, ErrNoMatch))
+	_, err = e.Extract("app-latest.tgz")
+	assert.EqualError(t, err, `invalid semantic version "latest": expected major.minor.patch`)
+}
+
+func Test_ExtractorDate(t *testing.T) {
+	e, err := NewExtractor(`backup-(\d{8})\.sql`, DateFormat)
+	assert.Nil(t, err)
+	e.Layout = "20060102"
+
+	extracted := e.ExtractAll([]string{"backup-20181101.sql", "backup-20180930.sql", "backup-2018.sql"})
+	assert.Equal(t, 2, len(extracted))
+	assert.Equal(t, "backup-20180930.sql", extracted[0].Name)
+	assert.Equal(t, "20181101", extracted[1].Version)
+	assert.Equal(t, 2018, extracted[1].Time.Year())
+}
+
+func Test_ExtractorNumber(t *testing.T) {
+	e, err := NewExtractor(`build-([0-9.]+)`, NumberFormat)
+	assert.Nil(t, err)
+
+	versions := e.Versions([]string{
+		"build-10",
+		"build-9",
+		"build-9.5",
+		"build-123456789012345678901234567890",
+		"build-123456789012345678901234567889",
+	}, "file")
+	assert.Equal(t, []Version{
+		{"file": "build-9"},
+		{"file": "build-9.5"},
+		{"file": "build-10"},
+		{"file": "build-123456789012345678901234567889"},
+		{"file": "build-123456789012345678901234567890"},
+	}, versions)
+}
+
+func Test_NewExtractorError(t *testing.T) {
+	_, err := NewExtractor(`app-.*`, SemVerFormat)
+	assert.EqualError(t, err, `regular expression "app-.*" has no capture group`)
+	_, err = NewExtractor(`app-(`, SemVerFormat)
+	assert.NotNil(t, err)
+}
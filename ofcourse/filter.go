@@ -0,0 +1,172 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const (
+	// IgnoreVersionsKey is the source key for a list of versions to drop from the
+	// result of Check. A version is dropped if it has all of the keys and values of
+	// any version in the list.
+	IgnoreVersionsKey = "ignore_versions"
+	// VersionRegexKey is the source key for a map of version keys to regular
+	// expressions. A version is only kept if the value of each key matches.
+	VersionRegexKey = "version_regex"
+	// InitialVersionKey is the source key for a version that is returned by Check
+	// when it is called without a version and finds no versions.
+	InitialVersionKey = "initial_version"
+)
+
+// VersionFilter filters the versions returned by Check. If a resource sets
+// VersionFilters in its Config, a VersionFilter is created from the source and
+// applied to the result of every Check.
+type VersionFilter struct {
+	Ignore  []Version
+	Regexps map[string]*regexp.Regexp
+	Initial Version
+}
+
+// VersionFilterFromSource creates a VersionFilter from the standard source keys
+// IgnoreVersionsKey, VersionRegexKey, and InitialVersionKey, all of which are optional.
+func VersionFilterFromSource(source Source) (*VersionFilter, error) {
+	filter := &VersionFilter{}
+
+	if _, ok := source[IgnoreVersionsKey]; ok {
+		value, err := source.Path(IgnoreVersionsKey)
+		if err != nil {
+			return nil, err
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, typeError("source", IgnoreVersionsKey, "array of versions", value)
+		}
+		for i, item := range items {
+			version, err := toVersion(fmt.Sprintf("%s.%d", IgnoreVersionsKey, i), item)
+			if err != nil {
+				return nil, err
+			}
+			filter.Ignore = append(filter.Ignore, version)
+		}
+	}
+
+	if _, ok := source[VersionRegexKey]; ok {
+		patterns, err := source.Map(VersionRegexKey)
+		if err != nil {
+			return nil, err
+		}
+		filter.Regexps = map[string]*regexp.Regexp{}
+		for key, value := range patterns {
+			pattern, ok := value.(string)
+			if !ok {
+				return nil, typeError("source", VersionRegexKey+"."+key, "string", value)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, &KeyError{Kind: "source", Key: VersionRegexKey + "." + key, Err: err}
+			}
+			filter.Regexps[key] = re
+		}
+	}
+
+	if value, ok := source[InitialVersionKey]; ok {
+		version, err := toVersion(InitialVersionKey, value)
+		if err != nil {
+			return nil, err
+		}
+		filter.Initial = version
+	}
+
+	return filter, nil
+}
+
+func toVersion(key string, value interface{}) (Version, error) {
+	m, ok := toMap(value)
+	if !ok {
+		return nil, typeError("source", key, "version", value)
+	}
+	version := Version{}
+	for k, v := range m {
+		switch s := v.(type) {
+		case string:
+			version[k] = s
+		case json.Number:
+			version[k] = s.String()
+		default:
+			return nil, typeError("source", key+"."+k, "string", v)
+		}
+	}
+	return version, nil
+}
+
+// Apply returns the versions that are not ignored and match the regular expressions,
+// keeping their order. If the cursor is nil and no versions remain, the initial
+// version is returned if there is one.
+func (f *VersionFilter) Apply(versions []Version, cursor Version) []Version {
+	keys := make([]string, 0, len(f.Regexps))
+	for key := range f.Regexps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	filtered := []Version{}
+	for _, version := range versions {
+		if f.ignored(version) || !f.matches(version, keys) {
+			continue
+		}
+		filtered = append(filtered, version)
+	}
+
+	if cursor == nil && len(filtered) == 0 && f.Initial != nil {
+		filtered = append(filtered, f.Initial)
+	}
+	return filtered
+}
+
+func (f *VersionFilter) ignored(version Version) bool {
+	for _, ignore := range f.Ignore {
+		matched := true
+		for k, v := range ignore {
+			if value, ok := version[k]; !ok || value != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *VersionFilter) matches(version Version, keys []string) bool {
+	for _, key := range keys {
+		value, ok := version[key]
+		if !ok || !f.Regexps[key].MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
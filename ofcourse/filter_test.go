@@ -0,0 +1,150 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type filteredResource struct {
+	emptyResource
+	versions []Version
+}
+
+func (r *filteredResource) Config() Config {
+	return Config{VersionFilters: true}
+}
+
+func (r *filteredResource) Check(source Source, version Version, env Environment,
+	logger *Logger) ([]Version, error) {
+	return r.versions, nil
+}
+
+func Test_VersionFilterApply(t *testing.T) {
+	versions := []Version{
+		{"tag": "v1.0.0", "sha": "a"},
+		{"tag": "v1.1.0-rc.1", "sha": "b"},
+		{"tag": "v1.1.0", "sha": "c"},
+		{"tag": "nightly", "sha": "d"},
+	}
+	tests := []struct {
+		source   string
+		cursor   Version
+		versions []Version
+		err      string
+	}{
+		{
+			`{}`,
+			nil,
+			versions,
+			"",
+		},
+		{
+			`{"ignore_versions":[{"sha":"c"},{"tag":"nightly","sha":"x"}]}`,
+			nil,
+			[]Version{versions[0], versions[1], versions[3]},
+			"",
+		},
+		{
+			`{"version_regex":{"tag":"^v\\d+\\.\\d+\\.\\d+$"}}`,
+			nil,
+			[]Version{versions[0], versions[2]},
+			"",
+		},
+		{
+			`{"version_regex":{"missing":".*"}}`,
+			nil,
+			[]Version{},
+			"",
+		},
+		{
+			`{"version_regex":{"tag":"^v2"},"initial_version":{"tag":"v0.0.0","sha":"0"}}`,
+			nil,
+			[]Version{{"tag": "v0.0.0", "sha": "0"}},
+			"",
+		},
+		{
+			`{"version_regex":{"tag":"^v2"},"initial_version":{"tag":"v0.0.0","sha":"0"}}`,
+			Version{"tag": "v1.0.0", "sha": "a"},
+			[]Version{},
+			"",
+		},
+		{
+			`{"initial_version":{"build":1}}`,
+			nil,
+			versions,
+			"",
+		},
+		{
+			`{"ignore_versions":{"sha":"c"}}`,
+			nil,
+			nil,
+			`source key "ignore_versions": expected array of versions but got object`,
+		},
+		{
+			`{"ignore_versions":[{"sha":true}]}`,
+			nil,
+			nil,
+			`source key "ignore_versions.0.sha": expected string but got bool`,
+		},
+		{
+			`{"version_regex":{"tag":"("}}`,
+			nil,
+			nil,
+			"source key \"version_regex.tag\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			`{"initial_version":"v1"}`,
+			nil,
+			nil,
+			`source key "initial_version": expected version but got string`,
+		},
+	}
+	for _, test := range tests {
+		var source Source
+		assert.Nil(t, json.Unmarshal([]byte(test.source), &source))
+		filter, err := VersionFilterFromSource(source)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		assert.Nil(t, err, test.source)
+		assert.Equal(t, test.versions, filter.Apply(versions, test.cursor), test.source)
+	}
+}
+
+func Test_checkVersionFilters(t *testing.T) {
+	resource := &filteredResource{versions: []Version{{"ref": "a"}, {"ref": "b"}}}
+
+	output, err := check(resource, []byte(`{"source":{"ignore_versions":[{"ref":"a"}]},"version":null}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`[{"ref":"b"}]`), output)
+
+	resource.versions = nil
+	output, err = check(resource, []byte(`{"source":{"initial_version":{"ref":"0"}},"version":null}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`[{"ref":"0"}]`), output)
+
+	_, err = check(resource, []byte(`{"source":{"version_regex":"a"},"version":null}`))
+	assert.EqualError(t, err, `source key "version_regex": expected object but got string`)
+}
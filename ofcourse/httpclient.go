@@ -0,0 +1,352 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Standard source keys read by NewHTTPClient.
+const (
+	// CACertsKey is a PEM encoded CA certificate bundle, or a list of them, to
+	// trust in addition to the system's certificates.
+	CACertsKey = "ca_certs"
+	// InsecureSkipVerifyKey disables verification of server certificates.
+	InsecureSkipVerifyKey = "insecure_skip_verify"
+	// ClientCertKey is a PEM encoded client certificate for mutual TLS.
+	ClientCertKey = "client_cert"
+	// ClientKeyKey is the PEM encoded private key of ClientCertKey.
+	ClientKeyKey = "client_key"
+	// ProxyKey is a proxy URL for all requests, overriding the environment.
+	ProxyKey = "proxy"
+	// NoProxyKey is a comma separated list of hosts which are not proxied,
+	// overriding the environment.
+	NoProxyKey = "no_proxy"
+	// TimeoutKey is the time limit of a request, including reading the
+	// response body, as a duration or a number of seconds.
+	TimeoutKey = "timeout"
+	// UserAgentKey is the User-Agent header sent with requests.
+	UserAgentKey = "user_agent"
+)
+
+// sensitiveParams are substrings of URL query parameter names whose values are
+// redacted when requests are logged.
+var sensitiveParams = []string{"auth", "credential", "key", "password", "secret",
+	"signature", "token"}
+
+// NewHTTPClient returns an HTTP client configured from the standard source keys
+// `ca_certs`, `insecure_skip_verify`, `client_cert`, `client_key`, `proxy`,
+// `no_proxy`, `timeout`, and `user_agent`. Unless set in the source, proxies are
+// taken from the `http_proxy`, `https_proxy`, and `no_proxy` variables of env.
+// Requests are logged at debug level, with the client key, proxy passwords, and
+// secrets in URLs redacted.
+func NewHTTPClient(source Source, env Environment, logger *Logger) (*http.Client, error) {
+	tlsConfig, err := tlsConfigFromSource(source)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := proxyFromSource(source, env)
+	if err != nil {
+		return nil, err
+	}
+	for _, proxyURL := range []*url.URL{proxy.http, proxy.https} {
+		if proxyURL == nil {
+			continue
+		}
+		if password, ok := proxyURL.User.Password(); ok {
+			logger.Redact(password)
+		}
+	}
+	if key, ok := source[ClientKeyKey].(string); ok {
+		logger.Redact(key)
+	}
+
+	var timeout time.Duration
+	if _, ok := source[TimeoutKey]; ok {
+		timeout, err = source.Duration(TimeoutKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var userAgent string
+	if _, ok := source[UserAgentKey]; ok {
+		userAgent, err = source.String(UserAgentKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = proxy.forRequest
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &loggingTransport{
+			base:      transport,
+			userAgent: userAgent,
+			logger:    logger,
+		},
+	}, nil
+}
+
+func tlsConfigFromSource(source Source) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if _, ok := source[InsecureSkipVerifyKey]; ok {
+		insecure, err := source.Bool(InsecureSkipVerifyKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.InsecureSkipVerify = insecure
+	}
+
+	if _, ok := source[CACertsKey]; ok {
+		var bundles []string
+		if bundle, ok := source[CACertsKey].(string); ok {
+			bundles = []string{bundle}
+		} else {
+			var err error
+			bundles, err = source.StringSlice(CACertsKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for i, bundle := range bundles {
+			if !pool.AppendCertsFromPEM([]byte(bundle)) {
+				key := CACertsKey
+				if len(bundles) > 1 {
+					key = fmt.Sprintf("%s.%d", CACertsKey, i)
+				}
+				return nil, &KeyError{Kind: "source", Key: key,
+					Err: fmt.Errorf("no PEM encoded certificates found")}
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	_, hasCert := source[ClientCertKey]
+	_, hasKey := source[ClientKeyKey]
+	if hasCert || hasKey {
+		cert, err := source.String(ClientCertKey)
+		if err != nil {
+			return nil, err
+		}
+		key, err := source.String(ClientKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, &KeyError{Kind: "source", Key: ClientCertKey, Err: err}
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	return tlsConfig, nil
+}
+
+type proxyConfig struct {
+	http    *url.URL
+	https   *url.URL
+	noProxy []string
+}
+
+func proxyFromSource(source Source, env Environment) (*proxyConfig, error) {
+	httpProxy := getEnvAny(env, "HTTP_PROXY", "http_proxy")
+	httpsProxy := getEnvAny(env, "HTTPS_PROXY", "https_proxy")
+	noProxy := getEnvAny(env, "NO_PROXY", "no_proxy")
+	if _, ok := source[ProxyKey]; ok {
+		proxy, err := source.String(ProxyKey)
+		if err != nil {
+			return nil, err
+		}
+		httpProxy, httpsProxy = proxy, proxy
+	}
+	if _, ok := source[NoProxyKey]; ok {
+		var err error
+		noProxy, err = source.String(NoProxyKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	config := &proxyConfig{}
+	var err error
+	config.http, err = parseProxy(httpProxy)
+	if err != nil {
+		return nil, err
+	}
+	config.https, err = parseProxy(httpsProxy)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range strings.Split(noProxy, ",") {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" {
+			config.noProxy = append(config.noProxy, host)
+		}
+	}
+	return config, nil
+}
+
+func getEnvAny(env Environment, names ...string) string {
+	for _, name := range names {
+		if value := env.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func parseProxy(proxy string) (*url.URL, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy address: %w", err)
+	}
+	return proxyURL, nil
+}
+
+// forRequest returns the proxy for a request, or nil if it should not be proxied.
+// As with net/http, requests to localhost are never proxied.
+func (p *proxyConfig) forRequest(req *http.Request) (*url.URL, error) {
+	proxy := p.http
+	if req.URL.Scheme == "https" {
+		proxy = p.https
+	}
+	if proxy == nil || !p.useProxy(req.URL) {
+		return nil, nil
+	}
+	return proxy, nil
+}
+
+func (p *proxyConfig) useProxy(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if host == "localhost" {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return false
+	}
+	for _, entry := range p.noProxy {
+		if entry == "*" {
+			return false
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return false
+			}
+			continue
+		}
+		entryHost, entryPort := entry, ""
+		if h, pt, err := net.SplitHostPort(entry); err == nil {
+			entryHost, entryPort = h, pt
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+		if strings.HasPrefix(entryHost, ".") {
+			if strings.HasSuffix(host, entryHost) {
+				return false
+			}
+			continue
+		}
+		if host == entryHost || strings.HasSuffix(host, "."+entryHost) {
+			return false
+		}
+	}
+	return true
+}
+
+type loggingTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	logger    *Logger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	target := RedactURL(req.URL)
+	t.logger.Debugf("%s %s", req.Method, target)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.logger.Debugf("%s %s failed after %s: %s", req.Method, target, elapsed, err)
+		return nil, err
+	}
+	t.logger.Debugf("%s %s: %s in %s", req.Method, target, resp.Status, elapsed)
+	return resp, nil
+}
+
+// RedactURL returns u as a string with any password and the values of query
+// parameters which appear to hold secrets, such as `access_token`, replaced by
+// RedactedValue.
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	if _, ok := redacted.User.Password(); ok {
+		redacted.User = url.UserPassword(redacted.User.Username(), RedactedValue)
+	}
+	if redacted.RawQuery != "" {
+		query := redacted.Query()
+		for name, values := range query {
+			if !isSensitiveParam(name) {
+				continue
+			}
+			for i := range values {
+				values[i] = RedactedValue
+			}
+		}
+		redacted.RawQuery = query.Encode()
+	}
+	s := redacted.String()
+	return strings.NewReplacer(url.QueryEscape(RedactedValue), RedactedValue,
+		url.PathEscape(RedactedValue), RedactedValue).Replace(s)
+}
+
+func isSensitiveParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveParams {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
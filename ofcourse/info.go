@@ -0,0 +1,198 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+)
+
+// modulePath is the path of this library's module, used to find its version in
+// the build info of a resource.
+const modulePath = "github.com/cloudboss/ofcourse"
+
+// InfoFlag is the flag which makes the `check` command print the resource's Info
+// instead of checking for versions.
+const InfoFlag = "--info"
+
+// SchemaInfo describes the source or params of a resource.
+type SchemaInfo struct {
+	Keys         []SchemaKey   `json:"keys"`
+	Deprecations []Deprecation `json:"deprecations"`
+}
+
+// Info describes a resource, as printed by its `info` command, so that operators
+// can inspect a resource image without reading its source.
+type Info struct {
+	Name            string       `json:"name"`
+	Version         string       `json:"version"`
+	OfcourseVersion string       `json:"ofcourse_version"`
+	GoVersion       string       `json:"go_version,omitempty"`
+	Operations      []string     `json:"operations"`
+	Source          SchemaInfo   `json:"source"`
+	Params          SchemaInfo   `json:"params"`
+	Outputs         []OutputFile `json:"outputs,omitempty"`
+}
+
+// ResourceInfo returns the description of a resource from its Config and the
+// build info of the running binary.
+func ResourceInfo(resource Resource) Info {
+	buildInfo, _ := debug.ReadBuildInfo()
+	return resourceInfo(resource, buildInfo)
+}
+
+func resourceInfo(resource Resource, buildInfo *debug.BuildInfo) Info {
+	config := configOf(resource)
+	info := Info{
+		Name:            config.Name,
+		Version:         "unknown",
+		OfcourseVersion: "unknown",
+		Operations:      []string{"check", "in", "out", "info"},
+		Source:          schemaInfo(config.Source, standardSourceKeys(config)),
+		Params:          schemaInfo(config.Params, standardParamsKeys(config)),
+		Outputs:         config.Outputs,
+	}
+	if buildInfo != nil {
+		info.GoVersion = buildInfo.GoVersion
+		if info.Name == "" && buildInfo.Main.Path != "" {
+			info.Name = path.Base(buildInfo.Main.Path)
+		}
+		if buildInfo.Main.Version != "" {
+			info.Version = buildInfo.Main.Version
+		}
+		if buildInfo.Main.Path == modulePath {
+			info.OfcourseVersion = info.Version
+		}
+		for _, dep := range buildInfo.Deps {
+			if dep.Path != modulePath {
+				continue
+			}
+			info.OfcourseVersion = dep.Version
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				info.OfcourseVersion = dep.Replace.Version
+			}
+		}
+	}
+	if info.Name == "" {
+		info.Name = filepath.Base(os.Args[0])
+	}
+	return info
+}
+
+func schemaInfo(schema Schema, standard []SchemaKey) SchemaInfo {
+	keys := append([]SchemaKey{}, schema.Keys...)
+	for _, key := range standard {
+		if !hasSchemaKey(keys, key.Name) {
+			keys = append(keys, key)
+		}
+	}
+	deprecations := schema.Deprecations
+	if deprecations == nil {
+		deprecations = []Deprecation{}
+	}
+	return SchemaInfo{Keys: keys, Deprecations: deprecations}
+}
+
+func hasSchemaKey(keys []SchemaKey, name string) bool {
+	for _, key := range keys {
+		if key.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// standardSourceKeys returns the source keys handled by this library for a resource.
+func standardSourceKeys(config Config) []SchemaKey {
+	keys := []SchemaKey{{
+		Name:        "log_level",
+		Type:        "string",
+		Description: `One of "silent", "error", "warn", "info", or "debug".`,
+	}}
+	if config.VersionFilters {
+		keys = append(keys,
+			SchemaKey{Name: IgnoreVersionsKey, Type: "array", Description: "Versions which check never returns."},
+			SchemaKey{Name: VersionRegexKey, Type: "object", Description: "Regular expressions which versions must match, by version key."},
+			SchemaKey{Name: InitialVersionKey, Type: "object", Description: "The version returned by check if there are no versions."},
+		)
+	}
+	if config.DryRun {
+		keys = append(keys, SchemaKey{Name: DryRunKey, Type: "bool", Description: "Make no changes on put."})
+	}
+	return keys
+}
+
+// standardParamsKeys returns the params keys handled by this library for a resource.
+func standardParamsKeys(config Config) []SchemaKey {
+	if config.DryRun {
+		return []SchemaKey{{Name: DryRunKey, Type: "bool", Description: "Make no changes on put."}}
+	}
+	return nil
+}
+
+// PrintInfo takes an implementation of Resource as its input, and prints a JSON
+// description of it, as returned by ResourceInfo.
+func PrintInfo(resource Resource) {
+	output, err := json.MarshalIndent(ResourceInfo(resource), "", "  ")
+	if err != nil {
+		internalLogger.Errorf("%s", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(output))
+}
+
+// Main takes an implementation of Resource as its input, and runs Check, In, Out,
+// or PrintInfo depending on the name the program is run as, so that a single
+// binary may be installed as /opt/resource/check, /opt/resource/in,
+// /opt/resource/out, and /opt/resource/info. The command may also be given as the
+// first argument, as in `resource info`.
+func Main(resource Resource) {
+	command := filepath.Base(os.Args[0])
+	if !isCommand(command) && len(os.Args) > 1 && isCommand(os.Args[1]) {
+		command = os.Args[1]
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+	switch command {
+	case "check":
+		Check(resource)
+	case "in":
+		In(resource)
+	case "out":
+		Out(resource)
+	case "info":
+		PrintInfo(resource)
+	default:
+		internalLogger.Errorf("unknown command %q, expected check, in, out, or info", command)
+		os.Exit(1)
+	}
+}
+
+func isCommand(name string) bool {
+	switch name {
+	case "check", "in", "out", "info":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type infoResource struct {
+	emptyResource
+	config Config
+}
+
+func (r *infoResource) Config() Config {
+	return r.config
+}
+
+func keyNames(keys []SchemaKey) []string {
+	names := []string{}
+	for _, key := range keys {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+func Test_resourceInfo(t *testing.T) {
+	testCases := []struct {
+		name            string
+		resource        Resource
+		buildInfo       *debug.BuildInfo
+		resourceName    string
+		version         string
+		ofcourseVersion string
+		sourceKeys      []string
+		paramsKeys      []string
+	}{
+		{
+			name:     "resource without config or build info",
+			resource: &emptyResource{},
+			// Falls back to the name of the test binary.
+			resourceName:    "",
+			version:         "unknown",
+			ofcourseVersion: "unknown",
+			sourceKeys:      []string{"log_level"},
+			paramsKeys:      []string{},
+		},
+		{
+			name:     "name and versions from build info",
+			resource: &emptyResource{},
+			buildInfo: &debug.BuildInfo{
+				Main: debug.Module{Path: "github.com/example/thing-resource", Version: "v1.2.3"},
+				Deps: []*debug.Module{
+					{Path: "github.com/stretchr/testify", Version: "v1.7.0"},
+					{Path: "github.com/cloudboss/ofcourse", Version: "v0.5.0"},
+				},
+			},
+			resourceName:    "thing-resource",
+			version:         "v1.2.3",
+			ofcourseVersion: "v0.5.0",
+			sourceKeys:      []string{"log_level"},
+			paramsKeys:      []string{},
+		},
+		{
+			name:     "replaced ofcourse module",
+			resource: &infoResource{config: Config{Name: "thing"}},
+			buildInfo: &debug.BuildInfo{
+				Main: debug.Module{Path: "github.com/example/thing-resource", Version: "(devel)"},
+				Deps: []*debug.Module{
+					{
+						Path:    "github.com/cloudboss/ofcourse",
+						Version: "v0.5.0",
+						Replace: &debug.Module{Path: "github.com/fork/ofcourse", Version: "v0.5.1"},
+					},
+				},
+			},
+			resourceName:    "thing",
+			version:         "(devel)",
+			ofcourseVersion: "v0.5.1",
+			sourceKeys:      []string{"log_level"},
+			paramsKeys:      []string{},
+		},
+		{
+			name: "declared and standard keys",
+			resource: &infoResource{config: Config{
+				Name: "thing",
+				Source: Schema{Keys: []SchemaKey{
+					{Name: "repository", Type: "string", Required: true},
+					{Name: "log_level", Type: "string", Description: "Overridden."},
+				}},
+				Params:         Schema{Keys: []SchemaKey{{Name: "path", Type: "string"}}},
+				VersionFilters: true,
+				DryRun:         true,
+			}},
+			resourceName:    "thing",
+			version:         "unknown",
+			ofcourseVersion: "unknown",
+			sourceKeys: []string{
+				"repository",
+				"log_level",
+				IgnoreVersionsKey,
+				VersionRegexKey,
+				InitialVersionKey,
+				DryRunKey,
+			},
+			paramsKeys: []string{"path", DryRunKey},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := resourceInfo(tc.resource, tc.buildInfo)
+			if tc.resourceName != "" {
+				assert.Equal(t, tc.resourceName, info.Name)
+			} else {
+				assert.NotEmpty(t, info.Name)
+			}
+			assert.Equal(t, tc.version, info.Version)
+			assert.Equal(t, tc.ofcourseVersion, info.OfcourseVersion)
+			assert.Equal(t, []string{"check", "in", "out", "info"}, info.Operations)
+			assert.Equal(t, tc.sourceKeys, keyNames(info.Source.Keys))
+			assert.Equal(t, tc.paramsKeys, keyNames(info.Params.Keys))
+			assert.NotNil(t, info.Source.Deprecations)
+			assert.NotNil(t, info.Params.Deprecations)
+		})
+	}
+}
+
+func Test_resourceInfoOfcourseModule(t *testing.T) {
+	info := resourceInfo(&emptyResource{}, &debug.BuildInfo{
+		Main: debug.Module{Path: "github.com/cloudboss/ofcourse", Version: "v0.6.0"},
+	})
+	assert.Equal(t, "ofcourse", info.Name)
+	assert.Equal(t, "v0.6.0", info.OfcourseVersion)
+}
+
+func Test_resourceInfoSchema(t *testing.T) {
+	config := Config{
+		Source: Schema{
+			Keys:         []SchemaKey{{Name: "uri", Type: "string", Required: true, Description: "Where."}},
+			Deprecations: []Deprecation{{Old: "url", New: "uri"}},
+		},
+		Outputs: Outputs{{Pattern: "version", Description: "The version."}},
+	}
+	info := resourceInfo(&infoResource{config: config}, nil)
+	assert.Equal(t, SchemaKey{Name: "uri", Type: "string", Required: true, Description: "Where."}, info.Source.Keys[0])
+	assert.Equal(t, []Deprecation{{Old: "url", New: "uri"}}, info.Source.Deprecations)
+	assert.Equal(t, []OutputFile{{Pattern: "version", Description: "The version."}}, info.Outputs)
+}
@@ -0,0 +1,297 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxMetadataEntries is the default limit on the number of metadata
+	// entries created by a MetadataBuilder.
+	DefaultMaxMetadataEntries = 50
+	// DefaultMaxMetadataValueLength is the default limit on the number of characters
+	// in a metadata value created by a MetadataBuilder.
+	DefaultMaxMetadataValueLength = 512
+	// DefaultTruncationMarker is appended to metadata values that are truncated.
+	DefaultTruncationMarker = "..."
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// MetadataBuilder builds Metadata from typed values, keeping entries in the order
+// they are added. Long values are truncated and the number of entries is capped,
+// so that large responses from remote APIs do not flood the Concourse UI.
+type MetadataBuilder struct {
+	// MaxEntries is the maximum number of entries, including the one noting
+	// omitted entries. Zero means no limit.
+	MaxEntries int
+	// MaxValueLength is the maximum number of characters in a value, including
+	// the truncation marker. Zero means no limit.
+	MaxValueLength int
+	// TruncationMarker is appended to values that have been truncated.
+	TruncationMarker string
+	// SkipEmpty causes entries with an empty value to be skipped.
+	SkipEmpty bool
+	// TimeLayout is used to format times, defaulting to time.RFC3339 if empty.
+	TimeLayout string
+
+	metadata Metadata
+}
+
+// NewMetadataBuilder returns a MetadataBuilder with the default limits.
+func NewMetadataBuilder() *MetadataBuilder {
+	return &MetadataBuilder{
+		MaxEntries:       DefaultMaxMetadataEntries,
+		MaxValueLength:   DefaultMaxMetadataValueLength,
+		TruncationMarker: DefaultTruncationMarker,
+	}
+}
+
+// String adds a string value.
+func (b *MetadataBuilder) String(name, value string) *MetadataBuilder {
+	if b.SkipEmpty && value == "" {
+		return b
+	}
+	b.metadata = append(b.metadata, NameVal{Name: name, Value: value})
+	return b
+}
+
+// Int adds an integer value.
+func (b *MetadataBuilder) Int(name string, value int64) *MetadataBuilder {
+	return b.String(name, strconv.FormatInt(value, 10))
+}
+
+// Float adds a floating point value.
+func (b *MetadataBuilder) Float(name string, value float64) *MetadataBuilder {
+	return b.String(name, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Bool adds a boolean value.
+func (b *MetadataBuilder) Bool(name string, value bool) *MetadataBuilder {
+	return b.String(name, strconv.FormatBool(value))
+}
+
+// Time adds a time formatted with TimeLayout. A zero time is empty.
+func (b *MetadataBuilder) Time(name string, value time.Time) *MetadataBuilder {
+	return b.String(name, b.formatTime(value, ""))
+}
+
+// Duration adds a duration such as "1m30s".
+func (b *MetadataBuilder) Duration(name string, value time.Duration) *MetadataBuilder {
+	return b.String(name, value.String())
+}
+
+// Bytes adds a size in bytes in human readable form, such as "1.5 MiB".
+func (b *MetadataBuilder) Bytes(name string, value int64) *MetadataBuilder {
+	return b.String(name, FormatBytes(value))
+}
+
+// URL adds a URL with any password redacted. A nil URL is empty.
+func (b *MetadataBuilder) URL(name string, value *url.URL) *MetadataBuilder {
+	if value == nil {
+		return b.String(name, "")
+	}
+	return b.String(name, value.Redacted())
+}
+
+// Map adds the entries of a map, sorted by key so the order is stable.
+func (b *MetadataBuilder) Map(values map[string]string) *MetadataBuilder {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.String(name, values[name])
+	}
+	return b
+}
+
+// Struct adds the fields of a struct, or a pointer to a struct, in the order they
+// are defined. Fields are named with the `metadata` struct tag, and fields without
+// the tag are skipped. The tag may include the option "omitempty" to skip an empty
+// value regardless of SkipEmpty, or "bytes" to format an integer as a size in bytes.
+// Times are formatted with the layout in the `layout` struct tag if given. Pointer
+// fields are formatted as the value they point to, and skipped if nil. Values that
+// are not a struct are ignored.
+//
+//	type release struct {
+//		Tag  string    `metadata:"tag"`
+//		Size int64     `metadata:"size,bytes"`
+//		Date time.Time `metadata:"date,omitempty" layout:"2006-01-02"`
+//	}
+func (b *MetadataBuilder) Struct(v interface{}) *MetadataBuilder {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return b
+	}
+	for _, field := range taggedFields(value.Type(), "metadata") {
+		options := strings.Split(field.structField.Tag.Get("metadata"), ",")[1:]
+		fieldValue := value.Field(field.index)
+		if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
+			continue
+		}
+		s := b.formatField(fieldValue, field.structField, options)
+		if s == "" && hasOption(options, "omitempty") {
+			continue
+		}
+		b.String(field.name, s)
+	}
+	return b
+}
+
+// Build returns the Metadata. Values longer than MaxValueLength are truncated, and
+// if there are more than MaxEntries, the last entry notes how many were omitted.
+func (b *MetadataBuilder) Build() Metadata {
+	metadata := Metadata{}
+	entries := b.metadata
+	omitted := 0
+	if b.MaxEntries > 0 && len(entries) > b.MaxEntries {
+		omitted = len(entries) - b.MaxEntries + 1
+		entries = entries[:b.MaxEntries-1]
+	}
+	for _, entry := range entries {
+		metadata = append(metadata, NameVal{
+			Name:  entry.Name,
+			Value: b.truncate(entry.Value),
+		})
+	}
+	if omitted > 0 {
+		metadata = append(metadata, NameVal{
+			Name:  "omitted",
+			Value: fmt.Sprintf("%d more entries", omitted),
+		})
+	}
+	return metadata
+}
+
+func (b *MetadataBuilder) truncate(value string) string {
+	if b.MaxValueLength <= 0 || utf8.RuneCountInString(value) <= b.MaxValueLength {
+		return value
+	}
+	keep := b.MaxValueLength - utf8.RuneCountInString(b.TruncationMarker)
+	if keep < 0 {
+		keep = 0
+	}
+	return string([]rune(value)[:keep]) + b.TruncationMarker
+}
+
+func (b *MetadataBuilder) formatTime(value time.Time, layout string) string {
+	if value.IsZero() {
+		return ""
+	}
+	if layout == "" {
+		layout = b.TimeLayout
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return value.Format(layout)
+}
+
+func (b *MetadataBuilder) formatField(value reflect.Value, structField reflect.StructField,
+	options []string) string {
+	switch value.Type() {
+	case timeType:
+		return b.formatTime(value.Interface().(time.Time), structField.Tag.Get("layout"))
+	case durationType:
+		return value.Interface().(time.Duration).String()
+	case urlType:
+		u := value.Interface().(url.URL)
+		return u.Redacted()
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		if u, ok := value.Interface().(*url.URL); ok {
+			return u.Redacted()
+		}
+		elem := value.Elem()
+		if value.Type().Implements(stringerType) && !elem.Type().Implements(stringerType) {
+			return value.Interface().(fmt.Stringer).String()
+		}
+		return b.formatField(elem, structField, options)
+	}
+	if value.Type().Implements(stringerType) {
+		return value.Interface().(fmt.Stringer).String()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if hasOption(options, "bytes") {
+			return FormatBytes(value.Int())
+		}
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if hasOption(options, "bytes") {
+			return FormatBytes(int64(value.Uint()))
+		}
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits())
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+func hasOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatBytes formats a number of bytes in human readable form using binary
+// units, such as "512 B", "1.5 KiB", or "2.0 GiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	exponent := 0
+	for value >= unit*unit || value <= -unit*unit {
+		value /= unit
+		exponent++
+	}
+	return fmt.Sprintf("%.1f %ciB", value/unit, "KMGTPE"[exponent])
+}
@@ -0,0 +1,288 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Standard source keys read by ClientCredentialsFromSource.
+const (
+	// TokenURLKey is the URL of the OAuth2 token endpoint.
+	TokenURLKey = "token_url"
+	// ClientIDKey is the OAuth2 client ID.
+	ClientIDKey = "client_id"
+	// ClientSecretKey is the OAuth2 client secret.
+	ClientSecretKey = "client_secret"
+	// ScopesKey is a list of OAuth2 scopes, or a string of space separated scopes.
+	ScopesKey = "scopes"
+)
+
+// tokenExpiryMargin is how long before its expiry a token is replaced, so that
+// it does not expire while a request is in flight.
+const tokenExpiryMargin = 30 * time.Second
+
+// Token is an OAuth2 access token.
+type Token struct {
+	AccessToken string
+	TokenType   string
+	// Expiry is when the token expires, or the zero time if it does not.
+	Expiry time.Time
+}
+
+// TokenError is returned when the token endpoint rejects a token request.
+type TokenError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *TokenError) Error() string {
+	message := fmt.Sprintf("token request failed with status %d", e.StatusCode)
+	if e.Code != "" {
+		message += ": " + e.Code
+	}
+	if e.Description != "" {
+		message += ": " + e.Description
+	}
+	return message
+}
+
+// Category returns TransientError if the token endpoint failed with a server error
+// or was rate limited, and PermanentError otherwise, such as for invalid credentials.
+func (e *TokenError) Category() ErrorCategory {
+	if e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests {
+		return TransientError
+	}
+	return PermanentError
+}
+
+// ClientCredentials gets OAuth2 access tokens with the client credentials grant,
+// caching each token until shortly before it expires. It is safe for concurrent use.
+type ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Client makes token requests, defaulting to http.DefaultClient if nil.
+	Client *http.Client
+	// Clock decides when tokens expire, defaulting to SystemClock if nil.
+	Clock Clock
+	// Logger redacts the client secret and access tokens from log messages.
+	Logger *Logger
+
+	mutex sync.Mutex
+	token *Token
+}
+
+// ClientCredentialsFromSource returns ClientCredentials configured from the
+// standard source keys `token_url`, `client_id`, `client_secret`, and `scopes`,
+// which make token requests with client, usually from NewHTTPClient. The client
+// secret is redacted from the logger's messages.
+func ClientCredentialsFromSource(source Source, client *http.Client,
+	logger *Logger) (*ClientCredentials, error) {
+	tokenURL, err := source.String(TokenURLKey)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := source.String(ClientIDKey)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := source.String(ClientSecretKey)
+	if err != nil {
+		return nil, err
+	}
+	logger.Redact(clientSecret)
+	var scopes []string
+	if _, ok := source[ScopesKey]; ok {
+		if s, ok := source[ScopesKey].(string); ok {
+			scopes = strings.Fields(s)
+		} else {
+			scopes, err = source.StringSlice(ScopesKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &ClientCredentials{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Client:       client,
+		Logger:       logger,
+	}, nil
+}
+
+// Token returns the cached token, or gets a new one if there is none or it is
+// about to expire.
+func (c *ClientCredentials) Token(ctx context.Context) (*Token, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := clockOrSystem(c.Clock).Now()
+	if c.token != nil && (c.token.Expiry.IsZero() || now.Add(tokenExpiryMargin).Before(c.token.Expiry)) {
+		return c.token, nil
+	}
+	token, err := c.fetch(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	return token, nil
+}
+
+// Invalidate discards the cached token, so that the next call to Token gets a
+// new one. This is useful when a server rejects a token before it expires.
+func (c *ClientCredentials) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.token = nil
+}
+
+func (c *ClientCredentials) fetch(ctx context.Context, now time.Time) (*Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		AccessToken      string      `json:"access_token"`
+		TokenType        string      `json:"token_type"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	err = json.Unmarshal(body, &result)
+	if resp.StatusCode != http.StatusOK {
+		return nil, &TokenError{
+			StatusCode:  resp.StatusCode,
+			Code:        result.Error,
+			Description: result.ErrorDescription,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("invalid token response: missing access_token")
+	}
+	if c.Logger != nil {
+		c.Logger.Redact(result.AccessToken)
+	}
+
+	token := &Token{AccessToken: result.AccessToken, TokenType: result.TokenType}
+	if token.TokenType == "" {
+		token.TokenType = "Bearer"
+	}
+	if result.ExpiresIn != "" {
+		seconds, err := result.ExpiresIn.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid token response: expires_in: %w", err)
+		}
+		if seconds > 0 {
+			token.Expiry = now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	return token, nil
+}
+
+// Transport returns an http.RoundTripper which adds an access token to requests
+// made with base, defaulting to http.DefaultTransport if nil. If a request is
+// rejected with a 401 status, the token is replaced and the request is sent once
+// more, if its body can be replayed.
+func (c *ClientCredentials) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &tokenTransport{base: base, credentials: c}
+}
+
+type tokenTransport struct {
+	base        http.RoundTripper
+	credentials *ClientCredentials
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTrip(req, req.Body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	body := req.Body
+	if body != nil && body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+	discardBody(resp)
+	t.credentials.Invalidate()
+	return t.roundTrip(req, body)
+}
+
+func (t *tokenTransport) roundTrip(req *http.Request, body io.ReadCloser) (*http.Response, error) {
+	token, err := t.credentials.Token(req.Context())
+	if err != nil {
+		// RoundTrip must close the request body, even on errors.
+		if body != nil {
+			body.Close()
+		}
+		return nil, err
+	}
+	authorized := req.Clone(req.Context())
+	authorized.Body = body
+	authorized.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+	return t.base.RoundTrip(authorized)
+}
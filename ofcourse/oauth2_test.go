@@ -0,0 +1,194 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenServer is a stand-in OAuth2 token endpoint which issues numbered tokens.
+type tokenServer struct {
+	*httptest.Server
+	issued int32
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	server := &tokenServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "resource" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client", "error_description": "bad credentials"}`)
+			return
+		}
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		n := atomic.AddInt32(&server.issued, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": fmt.Sprintf("token-%d:%s", n, r.PostForm.Get("scope")),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	return server
+}
+
+func Test_ClientCredentialsToken(t *testing.T) {
+	server := newTokenServer(t)
+	defer server.Close()
+
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	logger := &Logger{Level: debugLevel, Output: &buf}
+	source := Source{
+		"token_url":     server.URL,
+		"client_id":     "resource",
+		"client_secret": "s3cret",
+		"scopes":        []interface{}{"read", "write"},
+	}
+	credentials, err := ClientCredentialsFromSource(source, nil, logger)
+	assert.Nil(t, err)
+	credentials.Clock = ClockFunc(func() time.Time { return now })
+
+	token, err := credentials.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &Token{
+		AccessToken: "token-1:read write",
+		TokenType:   "Bearer",
+		Expiry:      now.Add(time.Hour),
+	}, token)
+
+	now = now.Add(59 * time.Minute)
+	token, err = credentials.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "token-1:read write", token.AccessToken)
+
+	now = now.Add(40 * time.Second)
+	token, err = credentials.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "token-2:read write", token.AccessToken)
+
+	credentials.Invalidate()
+	token, err = credentials.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "token-3:read write", token.AccessToken)
+
+	logger.Infof("secret s3cret, token token-3:read write")
+	assert.Equal(t, "\033[1;32msecret ((redacted)), token ((redacted))\033[0m\n", buf.String())
+
+	credentials.ClientSecret = "wrong"
+	credentials.Invalidate()
+	_, err = credentials.Token(context.Background())
+	assert.EqualError(t, err, "token request failed with status 401: invalid_client: bad credentials")
+	assert.Equal(t, PermanentError, CategoryOf(err))
+}
+
+func Test_ClientCredentialsFromSource(t *testing.T) {
+	source := Source{"token_url": "https://auth", "client_id": "id", "client_secret": "secret",
+		"scopes": "read write"}
+	credentials, err := ClientCredentialsFromSource(source, nil, NewLogger(SilentLevel))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"read", "write"}, credentials.Scopes)
+
+	delete(source, "client_secret")
+	_, err = ClientCredentialsFromSource(source, nil, NewLogger(SilentLevel))
+	assert.EqualError(t, err, `source key "client_secret": key is missing`)
+}
+
+func Test_ClientCredentialsTransport(t *testing.T) {
+	tokens := newTokenServer(t)
+	defer tokens.Close()
+
+	var revoked int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if auth == "Bearer token-1:" && atomic.LoadInt32(&revoked) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s", auth, body)
+	}))
+	defer api.Close()
+
+	source := Source{"token_url": tokens.URL, "client_id": "resource", "client_secret": "s3cret"}
+	client, err := NewHTTPClient(source, NewEnvironment(map[string]string{}), NewLogger(SilentLevel))
+	assert.Nil(t, err)
+	credentials, err := ClientCredentialsFromSource(source, client, NewLogger(SilentLevel))
+	assert.Nil(t, err)
+	apiClient := &http.Client{Transport: credentials.Transport(client.Transport)}
+
+	get := func(body string) string {
+		resp, err := apiClient.Post(api.URL, "text/plain", strings.NewReader(body))
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		assert.Nil(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "Bearer token-1: first", get("first"))
+	assert.Equal(t, "Bearer token-1: second", get("second"))
+	atomic.StoreInt32(&revoked, 1)
+	assert.Equal(t, "Bearer token-2: third", get("third"))
+	assert.Equal(t, int32(2), tokens.issued)
+}
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func Test_ClientCredentialsTransportTokenError(t *testing.T) {
+	tokens := newTokenServer(t)
+	defer tokens.Close()
+
+	credentials := &ClientCredentials{
+		TokenURL:     tokens.URL,
+		ClientID:     "resource",
+		ClientSecret: "wrong",
+		Client:       tokens.Client(),
+		Logger:       NewLogger(SilentLevel),
+	}
+	body := &closeTracker{Reader: strings.NewReader("payload")}
+	req, err := http.NewRequest(http.MethodPost, "http://example.com", body)
+	assert.Nil(t, err)
+	_, err = credentials.Transport(http.DefaultTransport).RoundTrip(req)
+	assert.NotNil(t, err)
+	assert.True(t, body.closed)
+}
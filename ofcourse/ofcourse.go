@@ -24,9 +24,11 @@ package ofcourse
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 )
 
 const (
@@ -42,6 +44,9 @@ const (
 	DebugLevel = "debug"
 )
 
+// RedactedValue replaces secrets in log messages.
+const RedactedValue = "((redacted))"
+
 const (
 	silentLevel = iota
 	errorLevel
@@ -52,6 +57,8 @@ const (
 
 var (
 	internalLogger = NewLogger(ErrorLevel)
+	// logMutex serializes writes so that loggers may be shared between goroutines.
+	logMutex sync.Mutex
 )
 
 // Logger is passed to resource functions so that they can log to the Concourse UI without
@@ -62,6 +69,12 @@ var (
 // colors are red, yellow, green, and blue.
 type Logger struct {
 	Level int
+	// Output is where log messages are written, defaulting to os.Stderr if nil.
+	Output io.Writer
+	// Prefix is prepended to every message.
+	Prefix string
+
+	secrets *[]string
 }
 
 // NewLogger returns a logger instance with the given log level, defaulting to "info" if
@@ -87,36 +100,86 @@ func NewLogger(level string) *Logger {
 
 // Errorf logs a red formatted string to the Concourse UI with newline.
 func (l *Logger) Errorf(message string, args ...interface{}) {
-	if l.Level >= errorLevel {
-		colorMessage := fmt.Sprintf("\033[1;31m%s\033[0m\n", message)
-		fmt.Fprintf(os.Stderr, colorMessage, args...)
-	}
+	l.logf(errorLevel, 31, message, args...)
 }
 
 // Warnf logs a yellow formatted string to the Concourse UI with newline.
 func (l *Logger) Warnf(message string, args ...interface{}) {
-	if l.Level >= warnLevel {
-		colorMessage := fmt.Sprintf("\033[1;33m%s\033[0m\n", message)
-		fmt.Fprintf(os.Stderr, colorMessage, args...)
-	}
+	l.logf(warnLevel, 33, message, args...)
 }
 
 // Infof logs a green formatted string to the Concourse UI with newline.
 func (l *Logger) Infof(message string, args ...interface{}) {
-	if l.Level >= infoLevel {
-		colorMessage := fmt.Sprintf("\033[1;32m%s\033[0m\n", message)
-		fmt.Fprintf(os.Stderr, colorMessage, args...)
-	}
+	l.logf(infoLevel, 32, message, args...)
 }
 
 // Debugf logs a blue formatted string to the Concourse UI with newline.
 func (l *Logger) Debugf(message string, args ...interface{}) {
-	if l.Level >= debugLevel {
-		colorMessage := fmt.Sprintf("\033[1;34m%s\033[0m\n", message)
-		fmt.Fprintf(os.Stderr, colorMessage, args...)
+	l.logf(debugLevel, 34, message, args...)
+}
+
+// WithPrefix returns a copy of the logger which prepends prefix to every message,
+// after any prefix the logger already has. This is useful for telling apart the
+// messages of tasks running concurrently.
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	if l.secrets == nil {
+		l.secrets = &[]string{}
+	}
+	logger := *l
+	logger.Prefix += prefix
+	return &logger
+}
+
+// Redact adds secrets, such as passwords and tokens, which are replaced with
+// RedactedValue wherever they appear in messages. Loggers created with WithPrefix
+// share their secrets with the logger they were created from.
+func (l *Logger) Redact(secrets ...string) {
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	if l.secrets == nil {
+		l.secrets = &[]string{}
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			*l.secrets = append(*l.secrets, secret)
+		}
 	}
 }
 
+// RedactString returns s with the logger's secrets replaced by RedactedValue.
+func (l *Logger) RedactString(s string) string {
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	return l.redact(s)
+}
+
+func (l *Logger) redact(s string) string {
+	if l.secrets == nil {
+		return s
+	}
+	for _, secret := range *l.secrets {
+		s = strings.Replace(s, secret, RedactedValue, -1)
+	}
+	return s
+}
+
+func (l *Logger) logf(level, color int, message string, args ...interface{}) {
+	if l.Level < level {
+		return
+	}
+	output := l.Output
+	if output == nil {
+		output = os.Stderr
+	}
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	colorMessage := fmt.Sprintf("\033[1;%dm%s%s\033[0m\n", color, l.Prefix,
+		l.redact(fmt.Sprintf(message, args...)))
+	io.WriteString(output, colorMessage)
+}
+
 type environment struct {
 	variables map[string]string
 }
@@ -239,12 +302,30 @@ func check(resource Resource, input []byte) ([]byte, error) {
 		logger = NewLogger(logLevel)
 	}
 
-	versions, err := resource.Check(checkInput.Source, checkInput.Version,
+	config := configOf(resource)
+	source, err := checkInput.Source.Migrate(config.Source, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *VersionFilter
+	if config.VersionFilters {
+		filter, err = VersionFilterFromSource(source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	versions, err := resource.Check(source, checkInput.Version,
 		NewEnvironment(), logger)
 	if err != nil {
 		return nil, err
 	}
 
+	if filter != nil {
+		versions = filter.Apply(versions, checkInput.Version)
+	}
+
 	versionBytes, err := json.Marshal(versions)
 	if err != nil {
 		return nil, err
@@ -265,12 +346,39 @@ func in(resource Resource, outDir string, input []byte) ([]byte, error) {
 		logger = NewLogger(logLevel)
 	}
 
-	version, metadata, err := resource.In(outDir, inInput.Source, inInput.Params,
+	config := configOf(resource)
+	source, err := inInput.Source.Migrate(config.Source, logger)
+	if err != nil {
+		return nil, err
+	}
+	params, err := inInput.Params.Migrate(config.Params, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	version, metadata, err := resource.In(outDir, source, params,
 		inInput.Version, NewEnvironment(), logger)
 	if err != nil {
 		return nil, err
 	}
 
+	if config.ResultFiles {
+		err = WriteResultFiles(outDir, version, metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = config.Outputs.Verify(outDir, params)
+	if err != nil {
+		if config.ResultFiles {
+			// Do not leave valid looking results in an output directory
+			// which failed the check.
+			removeResultFiles(outDir, version)
+		}
+		return nil, err
+	}
+
 	output := inOutOutput{
 		Version:  version,
 		Metadata: metadata,
@@ -290,11 +398,31 @@ func out(resource Resource, inDir string, input []byte) ([]byte, error) {
 		logger = NewLogger(logLevel)
 	}
 
-	version, metadata, err := resource.Out(inDir, outInput.Source,
-		outInput.Params, NewEnvironment(), logger)
+	config := configOf(resource)
+	source, err := outInput.Source.Migrate(config.Source, logger)
 	if err != nil {
 		return nil, err
 	}
+	params, err := outInput.Params.Migrate(config.Params, logger)
+	if err != nil {
+		return nil, err
+	}
+	dryRun, err := prepareDryRun(config, source, params)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		logger.Infof("dry run, no changes will be made")
+	}
+
+	version, metadata, err := resource.Out(inDir, source, params,
+		NewEnvironment(), logger)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		metadata = tagDryRun(metadata)
+	}
 
 	output := inOutOutput{
 		Version:  version,
@@ -305,21 +433,30 @@ func out(resource Resource, inDir string, input []byte) ([]byte, error) {
 
 // Check takes an implementation of Resource as its input. The Main function
 // for the /opt/resource/check command that is run by Concourse should create
-// an instance of the resource and pass it to this function.
+// an instance of the resource and pass it to this function. When run with
+// the argument --info, it prints the resource's description instead, see
+// PrintInfo.
 func Check(resource Resource) {
+	if len(os.Args) > 1 && os.Args[1] == InfoFlag {
+		PrintInfo(resource)
+		return
+	}
+
 	input, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
-		internalLogger.Errorf(err.Error())
+		internalLogger.Errorf("%s", err)
 		os.Exit(1)
 	}
 
+	endRun := startRun()
 	output, err := check(resource, input)
+	endRun()
 	if err != nil {
-		internalLogger.Errorf(err.Error())
+		internalLogger.Errorf("%s", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf(string(output))
+	fmt.Print(string(output))
 }
 
 // In takes an implementation of Resource as its input. The Main function
@@ -334,17 +471,19 @@ func In(resource Resource) {
 
 	input, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
-		internalLogger.Errorf(err.Error())
+		internalLogger.Errorf("%s", err)
 		os.Exit(1)
 	}
 
+	endRun := startRun()
 	output, err := in(resource, outDir, input)
+	endRun()
 	if err != nil {
-		internalLogger.Errorf(err.Error())
+		internalLogger.Errorf("%s", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf(string(output))
+	fmt.Print(string(output))
 }
 
 // Out takes an implementation of Resource as its input. The Main function
@@ -359,15 +498,17 @@ func Out(resource Resource) {
 
 	input, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
-		internalLogger.Errorf(err.Error())
+		internalLogger.Errorf("%s", err)
 		os.Exit(1)
 	}
 
+	endRun := startRun()
 	output, err := out(resource, inDir, input)
+	endRun()
 	if err != nil {
-		internalLogger.Errorf(err.Error())
+		internalLogger.Errorf("%s", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf(string(output))
+	fmt.Print(string(output))
 }
@@ -20,6 +20,7 @@
 package ofcourse
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -311,3 +312,16 @@ func Test_environmentGetAll(t *testing.T) {
 		assert.Equal(t, test.ok, ok)
 	}
 }
+
+func Test_LoggerRedact(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{Level: infoLevel, Output: &buf}
+	task := logger.WithPrefix("[task] ")
+	logger.Redact("hunter2", "")
+	task.Redact("t0ken")
+	logger.Infof("password is %s", "hunter2")
+	task.Infof("password is hunter2, token is t0ken")
+	assert.Equal(t, "\033[1;32mpassword is ((redacted))\033[0m\n"+
+		"\033[1;32m[task] password is ((redacted)), token is ((redacted))\033[0m\n", buf.String())
+	assert.Equal(t, "token is ((redacted))", logger.RedactString("token is t0ken"))
+}
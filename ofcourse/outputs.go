@@ -0,0 +1,123 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// OutputFile declares a file that In promises to place in its output directory.
+type OutputFile struct {
+	// Pattern is a file name or glob pattern relative to the output directory,
+	// such as "version" or "*.tgz". At least one file must match.
+	Pattern string `json:"pattern"`
+	// Description is a short explanation of the file's contents, used in
+	// documentation.
+	Description string `json:"description,omitempty"`
+	// Param, if set, is the name of a `get` parameter which the file depends on.
+	// The file is only required if the parameter is set to a value other than
+	// false, null, or an empty string.
+	Param string `json:"param,omitempty"`
+}
+
+// Outputs is the contract of files produced by In. If a resource sets Outputs in
+// its Config, the `in` dispatcher verifies the output directory after In returns.
+type Outputs []OutputFile
+
+// OutputsError is returned when the output directory does not meet the contract.
+type OutputsError struct {
+	Missing []OutputFile
+}
+
+func (e *OutputsError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, file := range e.Missing {
+		missing[i] = fmt.Sprintf("%q", file.Pattern)
+		if file.Param != "" {
+			missing[i] += fmt.Sprintf(" (required by param %q)", file.Param)
+		}
+	}
+	noun := "file"
+	if len(missing) > 1 {
+		noun = "files"
+	}
+	return fmt.Sprintf("output directory is missing %s %s", noun, strings.Join(missing, ", "))
+}
+
+// Verify checks that dir contains a match for each file in the contract that is
+// required with the given params, returning an OutputsError listing every missing
+// file. It may be used in tests of a resource's In method.
+func (o Outputs) Verify(dir string, params Params) error {
+	var missing []OutputFile
+	for _, file := range o {
+		if !file.required(params) {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(file.Pattern)))
+		if err != nil {
+			return fmt.Errorf("invalid output pattern %q: %w", file.Pattern, err)
+		}
+		if len(matches) == 0 {
+			missing = append(missing, file)
+		}
+	}
+	if len(missing) > 0 {
+		return &OutputsError{Missing: missing}
+	}
+	return nil
+}
+
+// Markdown returns the contract as a Markdown list in the style of the generated
+// README, for documenting the files produced by `in`.
+func (o Outputs) Markdown() string {
+	var b strings.Builder
+	for i, file := range o {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "* `%s`:", file.Pattern)
+		if file.Param != "" {
+			fmt.Fprintf(&b, " *When `%s` is set.*", file.Param)
+		}
+		if file.Description != "" {
+			fmt.Fprintf(&b, " %s", file.Description)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (f OutputFile) required(params Params) bool {
+	if f.Param == "" {
+		return true
+	}
+	switch value := params[f.Param].(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		return value != ""
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOutputs = Outputs{
+	{Pattern: "version", Description: "The fetched version."},
+	{Pattern: "*.tgz", Description: "The release tarball."},
+	{Pattern: "docs/index.html", Description: "Documentation.", Param: "docs"},
+}
+
+type outputsResource struct {
+	emptyResource
+	files       map[string]string
+	resultFiles bool
+}
+
+func (r *outputsResource) Config() Config {
+	return Config{Outputs: testOutputs, ResultFiles: r.resultFiles}
+}
+
+func (r *outputsResource) In(outDir string, source Source, params Params,
+	version Version, env Environment, logger *Logger) (Version, Metadata, error) {
+	for name, content := range r.files {
+		file := filepath.Join(outDir, name)
+		err := os.MkdirAll(filepath.Dir(file), 0755)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = ioutil.WriteFile(file, []byte(content), 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return version, nil, nil
+}
+
+func Test_OutputsVerify(t *testing.T) {
+	tests := []struct {
+		files  map[string]string
+		params Params
+		err    string
+	}{
+		{
+			map[string]string{"version": "1", "app-1.0.tgz": "", "other.txt": ""},
+			nil,
+			"",
+		},
+		{
+			map[string]string{"version": "1", "app-1.0.tgz": ""},
+			Params{"docs": false},
+			"",
+		},
+		{
+			map[string]string{"version": "1", "app-1.0.tgz": ""},
+			Params{"docs": ""},
+			"",
+		},
+		{
+			map[string]string{"version": "1", "app-1.0.tgz": ""},
+			Params{"docs": true},
+			`output directory is missing file "docs/index.html" (required by param "docs")`,
+		},
+		{
+			map[string]string{"version": "1", "app-1.0.tgz": "", "docs/index.html": ""},
+			Params{"docs": "all"},
+			"",
+		},
+		{
+			map[string]string{"app-1.0.zip": ""},
+			nil,
+			`output directory is missing files "version", "*.tgz"`,
+		},
+	}
+	for _, test := range tests {
+		dir, err := ioutil.TempDir("", "ofcourse")
+		assert.Nil(t, err)
+		writeTestFiles(t, dir, test.files)
+		err = testOutputs.Verify(dir, test.params)
+		if test.err == "" {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, test.err)
+		}
+		os.RemoveAll(dir)
+	}
+
+	err := Outputs{{Pattern: "[a"}}.Verify(os.TempDir(), nil)
+	assert.EqualError(t, err, `invalid output pattern "[a": syntax error in pattern`)
+}
+
+func Test_OutputsMarkdown(t *testing.T) {
+	expected := "* `version`: The fetched version.\n\n" +
+		"* `*.tgz`: The release tarball.\n\n" +
+		"* `docs/index.html`: *When `docs` is set.* Documentation.\n"
+	assert.Equal(t, expected, testOutputs.Markdown())
+	assert.Equal(t, "", Outputs{}.Markdown())
+}
+
+func Test_inOutputs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	resource := &outputsResource{files: map[string]string{"version": "1"}}
+	_, err = in(resource, dir, []byte(`{"source": {}, "params": {"docs": true}, "version": {}}`))
+	assert.EqualError(t, err, `output directory is missing files "*.tgz", `+
+		`"docs/index.html" (required by param "docs")`)
+
+	resource.files["app.tgz"] = ""
+	_, err = in(resource, dir, []byte(`{"source": {}, "params": {}, "version": {}}`))
+	assert.Nil(t, err)
+}
+
+func Test_inOutputsResultFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	resource := &outputsResource{files: map[string]string{"version": "1"}, resultFiles: true}
+	input := []byte(`{"source": {}, "params": {}, "version": {"ref": "abc123"}}`)
+	_, err = in(resource, dir, input)
+	assert.EqualError(t, err, `output directory is missing file "*.tgz"`)
+	assert.Equal(t, []string{"version"}, listFiles(t, dir))
+
+	resource.files["app.tgz"] = ""
+	_, err = in(resource, dir, input)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"app.tgz", "metadata.json", "ref", "version", "version.json"}, listFiles(t, dir))
+}
@@ -0,0 +1,300 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Page is one page of a paginated listing fetched by a Pager.
+type Page struct {
+	// Number is the page's position in the listing, starting at 1.
+	Number   int
+	Request  *http.Request
+	Response *http.Response
+	// Body is the page's response body, which has already been read.
+	Body []byte
+}
+
+// Decode unmarshals the page's JSON body into v.
+func (p *Page) Decode(v interface{}) error {
+	return json.Unmarshal(p.Body, v)
+}
+
+// Field returns the value at a dot separated path such as "meta.next" in the
+// page's JSON body, with numbers as json.Number. An empty path returns the whole
+// body.
+func (p *Page) Field(path string) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(p.Body))
+	decoder.UseNumber()
+	var body interface{}
+	err := decoder.Decode(&body)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return body, nil
+	}
+	m, ok := toMap(body)
+	if !ok {
+		return nil, fmt.Errorf("response body is %s, not an object", typeName(body))
+	}
+	return configPath("response", m, path)
+}
+
+// Pagination finds the request for the page after a given page.
+type Pagination interface {
+	// Next returns the request for the page after page, or nil if it is the
+	// last page.
+	Next(page *Page) (*http.Request, error)
+}
+
+// LinkPagination follows the URL with `rel="next"` in the Link header of each page,
+// as used by GitHub and RFC 8288.
+type LinkPagination struct{}
+
+// Next returns a request for the next link, if any.
+func (LinkPagination) Next(page *Page) (*http.Request, error) {
+	for _, header := range page.Response.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, ok := nextLink(link)
+			if !ok {
+				continue
+			}
+			next, err := page.Request.URL.Parse(target)
+			if err != nil {
+				return nil, fmt.Errorf("invalid next link %q: %w", target, err)
+			}
+			return withURL(page.Request, next), nil
+		}
+	}
+	return nil, nil
+}
+
+// nextLink returns the URL of a link such as `<https://host/?page=2>; rel="next"`
+// if its relation is "next".
+func nextLink(link string) (string, bool) {
+	parts := strings.Split(link, ";")
+	target := strings.TrimSpace(parts[0])
+	if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+		return "", false
+	}
+	for _, param := range parts[1:] {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 || strings.ToLower(kv[0]) != "rel" {
+			continue
+		}
+		for _, rel := range strings.Fields(strings.Trim(kv[1], `"`)) {
+			if strings.ToLower(rel) == "next" {
+				return target[1 : len(target)-1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// CursorPagination reads a cursor token from each page's JSON body and passes it to
+// the next request as a query parameter. The last page has no cursor, or an empty
+// or null one.
+type CursorPagination struct {
+	// Field is the dot separated path of the cursor in the body, such as
+	// "meta.next_cursor".
+	Field string
+	// Param is the query parameter which passes the cursor, such as "cursor".
+	Param string
+}
+
+// Next returns a request with the page's cursor, if it has one.
+func (c CursorPagination) Next(page *Page) (*http.Request, error) {
+	value, err := page.Field(c.Field)
+	if err != nil {
+		if isMissingKey(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cursor string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		cursor = v
+	case json.Number:
+		cursor = v.String()
+	default:
+		return nil, typeError("response", c.Field, "string", value)
+	}
+	if cursor == "" {
+		return nil, nil
+	}
+	return withQuery(page.Request, c.Param, cursor), nil
+}
+
+// PageNumberPagination increments a page number query parameter until a page has
+// no items.
+type PageNumberPagination struct {
+	// Param is the query parameter with the page number, such as "page".
+	Param string
+	// Items is the dot separated path of the array of items in the body, or
+	// empty if the body itself is the array.
+	Items string
+}
+
+// Next returns a request for the following page number, unless the page is empty.
+func (p PageNumberPagination) Next(page *Page) (*http.Request, error) {
+	value, err := page.Field(p.Items)
+	if err != nil {
+		if isMissingKey(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	items, ok := value.([]interface{})
+	if !ok && value != nil {
+		return nil, fmt.Errorf("page items: expected array but got %s", typeName(value))
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	number := 1
+	if current := page.Request.URL.Query().Get(p.Param); current != "" {
+		number, err = strconv.Atoi(current)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page number %q: %w", current, err)
+		}
+	}
+	return withQuery(page.Request, p.Param, strconv.Itoa(number+1)), nil
+}
+
+func isMissingKey(err error) bool {
+	keyErr, ok := err.(*KeyError)
+	return ok && keyErr.Err == ErrMissingKey
+}
+
+func withURL(req *http.Request, u *url.URL) *http.Request {
+	next := req.Clone(req.Context())
+	next.URL = u
+	next.Host = ""
+	return next
+}
+
+func withQuery(req *http.Request, param, value string) *http.Request {
+	u := *req.URL
+	query := u.Query()
+	query.Set(param, value)
+	u.RawQuery = query.Encode()
+	return withURL(req, &u)
+}
+
+// Pager fetches the pages of a paginated listing one at a time.
+type Pager struct {
+	// Client makes the requests, usually from NewHTTPClient, defaulting to
+	// http.DefaultClient if nil.
+	Client *http.Client
+	// Pagination finds the next page.
+	Pagination Pagination
+	// Retry, if set, retries the fetching of each page.
+	Retry *Retry
+	// Progress, if set, reports the bytes of each page as they are read.
+	Progress *Progress
+	// MaxPages limits the number of pages fetched, if greater than zero.
+	MaxPages int
+}
+
+// Each fetches the pages starting with req, which must not have a body, and calls
+// f with each one. It stops after the last page, when MaxPages have been fetched,
+// or when f returns true, such as when it finds the cursor version of Check.
+// Responses with a status other than 2xx fail with an HTTPStatusError.
+func (p *Pager) Each(ctx context.Context, req *http.Request, f func(page *Page) (bool, error)) error {
+	req = req.WithContext(ctx)
+	for number := 1; req != nil; number++ {
+		if p.MaxPages > 0 && number > p.MaxPages {
+			return nil
+		}
+		page, err := p.fetch(ctx, req, number)
+		if err != nil {
+			return fmt.Errorf("fetching page %d: %w", number, err)
+		}
+		stop, err := f(page)
+		if err != nil || stop {
+			return err
+		}
+		req, err = p.Pagination.Next(page)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pager) fetch(ctx context.Context, req *http.Request, number int) (*Page, error) {
+	var page *Page
+	fetch := func(ctx context.Context) error {
+		var err error
+		page, err = p.fetchOnce(req, number)
+		return err
+	}
+	var err error
+	if p.Retry == nil {
+		err = fetch(ctx)
+	} else {
+		err = p.Retry.Do(ctx, fmt.Sprintf("fetching page %d of %s", number, RedactURL(req.URL)), fetch)
+	}
+	return page, err
+}
+
+func (p *Pager) fetchOnce(req *http.Request, number int) (*Page, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var body io.Reader = resp.Body
+	if p.Progress != nil {
+		body = p.Progress.Reader(body)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return &Page{Number: number, Request: req, Response: resp, Body: data}, nil
+}
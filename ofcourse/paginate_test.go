@@ -0,0 +1,219 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// releases is a listing of 7 releases, newest first, served 3 per page.
+var releases = []string{"v7", "v6", "v5", "v4", "v3", "v2", "v1"}
+
+func releasesPage(start int) []string {
+	if start >= len(releases) {
+		return []string{}
+	}
+	end := start + 3
+	if end > len(releases) {
+		end = len(releases)
+	}
+	return releases[start:end]
+}
+
+func newReleasesServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/link":
+			start, _ := strconv.Atoi(query.Get("start"))
+			if start+3 < len(releases) {
+				w.Header().Add("Link", fmt.Sprintf(`</link?start=%d>; rel="next", </link?start=6>; rel="last"`, start+3))
+			}
+			json.NewEncoder(w).Encode(releasesPage(start))
+		case "/cursor":
+			start, _ := strconv.Atoi(query.Get("after"))
+			next := interface{}(nil)
+			if start+3 < len(releases) {
+				next = strconv.Itoa(start + 3)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": releasesPage(start),
+				"meta": map[string]interface{}{"next": next},
+			})
+		case "/pages":
+			page, _ := strconv.Atoi(query.Get("page"))
+			if page == 0 {
+				page = 1
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": releasesPage((page - 1) * 3)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_PagerEach(t *testing.T) {
+	var requests int32
+	server := newReleasesServer(t, &requests)
+	defer server.Close()
+
+	tests := []struct {
+		path       string
+		pagination Pagination
+		field      string
+		requests   int32
+	}{
+		{"/link", LinkPagination{}, "", 3},
+		{"/cursor", CursorPagination{Field: "meta.next", Param: "after"}, "data", 3},
+		{"/pages", PageNumberPagination{Param: "page", Items: "items"}, "items", 4},
+	}
+	for _, test := range tests {
+		atomic.StoreInt32(&requests, 0)
+		pager := &Pager{Pagination: test.pagination}
+		req, err := http.NewRequest("GET", server.URL+test.path, nil)
+		assert.Nil(t, err)
+
+		var names []string
+		var numbers []int
+		err = pager.Each(context.Background(), req, func(page *Page) (bool, error) {
+			numbers = append(numbers, page.Number)
+			value, err := page.Field(test.field)
+			if err != nil {
+				return false, err
+			}
+			for _, item := range value.([]interface{}) {
+				names = append(names, item.(string))
+			}
+			return false, nil
+		})
+		assert.Nil(t, err, test.path)
+		assert.Equal(t, releases, names, test.path)
+		assert.Equal(t, test.requests, requests, test.path)
+		assert.Equal(t, 1, numbers[0])
+
+		// Stop once the cursor version is found, fetching only the pages up to it.
+		atomic.StoreInt32(&requests, 0)
+		err = pager.Each(context.Background(), req, func(page *Page) (bool, error) {
+			return page.Number == 2, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), requests, test.path)
+	}
+}
+
+func Test_PagerErrors(t *testing.T) {
+	var requests int32
+	server := newReleasesServer(t, &requests)
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL+"/link", nil)
+	assert.Nil(t, err)
+	pager := &Pager{Pagination: LinkPagination{}, MaxPages: 2}
+	var items [][]string
+	err = pager.Each(context.Background(), req, func(page *Page) (bool, error) {
+		var names []string
+		err := page.Decode(&names)
+		items = append(items, names)
+		return false, err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"v7", "v6", "v5"}, {"v4", "v3", "v2"}}, items)
+	assert.Equal(t, int32(2), requests)
+
+	req, err = http.NewRequest("GET", server.URL+"/missing", nil)
+	assert.Nil(t, err)
+	err = pager.Each(context.Background(), req, func(page *Page) (bool, error) { return false, nil })
+	assert.EqualError(t, err, "fetching page 1: 404 Not Found")
+	assert.Equal(t, PermanentError, CategoryOf(err))
+
+	req, err = http.NewRequest("GET", server.URL+"/link", nil)
+	assert.Nil(t, err)
+	pager = &Pager{Pagination: CursorPagination{Field: "meta.next", Param: "after"}}
+	err = pager.Each(context.Background(), req, func(page *Page) (bool, error) { return false, nil })
+	assert.EqualError(t, err, "response body is array, not an object")
+}
+
+func Test_PagerRetryProgress(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": releasesPage(0)})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	var buf bytes.Buffer
+	logger := &Logger{Level: infoLevel, Output: &buf}
+	progress := NewProgress("releases", 0, logger)
+	pager := &Pager{
+		Pagination: PageNumberPagination{Param: "page", Items: "items"},
+		Retry:      testRetry(NewLogger(SilentLevel), &delays),
+		Progress:   progress,
+		MaxPages:   2,
+	}
+	req, err := http.NewRequest("GET", server.URL, nil)
+	assert.Nil(t, err)
+	pages := 0
+	err = pager.Each(context.Background(), req, func(page *Page) (bool, error) {
+		pages++
+		return false, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, int32(4), requests)
+	assert.Len(t, delays, 2)
+	progress.Done()
+	assert.Contains(t, buf.String(), "releases: 54 B in ")
+}
+
+func Test_nextLink(t *testing.T) {
+	tests := []struct {
+		link   string
+		target string
+		ok     bool
+	}{
+		{`<https://api/items?page=2>; rel="next"`, "https://api/items?page=2", true},
+		{` <https://api/items?page=2>; rel=next`, "https://api/items?page=2", true},
+		{`<https://api/items?page=2>; title="x"; rel="prev next"`, "https://api/items?page=2", true},
+		{`<https://api/items?page=9>; rel="last"`, "", false},
+		{`https://api/items?page=2; rel="next"`, "", false},
+	}
+	for _, test := range tests {
+		target, ok := nextLink(test.link)
+		assert.Equal(t, test.target, target, test.link)
+		assert.Equal(t, test.ok, ok, test.link)
+	}
+}
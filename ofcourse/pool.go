@@ -0,0 +1,210 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// ParallelismKey is the standard source key for the number of tasks a Pool
+	// runs at once.
+	ParallelismKey = "parallelism"
+	// DefaultParallelism is the number of tasks a Pool runs at once if the
+	// source does not set ParallelismKey.
+	DefaultParallelism = 4
+)
+
+// ErrorMode determines how a Pool handles a failed task.
+type ErrorMode int
+
+const (
+	// FailFast cancels the remaining tasks when one fails.
+	FailFast ErrorMode = iota
+	// CollectAll runs every task, returning all of the errors.
+	CollectAll
+)
+
+// Task is a unit of work run by a Pool. It should stop when ctx is canceled. The
+// logger prefixes its messages with the name of the task.
+type Task func(ctx context.Context, logger *Logger) (interface{}, error)
+
+// TaskError is the error of one task in a Pool.
+type TaskError struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Err)
+}
+
+// Unwrap returns the task's error.
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// TaskErrors is returned by Pool.Wait in CollectAll mode, in submission order.
+type TaskErrors []*TaskError
+
+func (e TaskErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d of the tasks failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Pool runs tasks concurrently with bounded parallelism, collecting their results
+// in the order the tasks were submitted.
+type Pool struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mode    ErrorMode
+	logger  *Logger
+	slots   chan struct{}
+	wg      sync.WaitGroup
+	mutex   sync.Mutex
+	results []interface{}
+	errs    TaskErrors
+	failure *TaskError
+	// skipped is set when a task is not run because the pool was canceled.
+	skipped bool
+}
+
+// NewPool returns a pool which runs up to parallelism tasks at once. The tasks'
+// context is derived from ctx, which is usually RunContext().
+func NewPool(ctx context.Context, parallelism int, mode ErrorMode, logger *Logger) *Pool {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Pool{
+		ctx:    ctx,
+		cancel: cancel,
+		mode:   mode,
+		logger: logger,
+		slots:  make(chan struct{}, parallelism),
+	}
+}
+
+// PoolFromSource returns a pool bound to the run's context, with parallelism set by
+// the source key `parallelism`, defaulting to DefaultParallelism.
+func PoolFromSource(source Source, mode ErrorMode, logger *Logger) (*Pool, error) {
+	parallelism := int64(DefaultParallelism)
+	if _, ok := source[ParallelismKey]; ok {
+		var err error
+		parallelism, err = source.Int(ParallelismKey)
+		if err != nil {
+			return nil, err
+		}
+		if parallelism < 1 {
+			return nil, &KeyError{
+				Kind: "source",
+				Key:  ParallelismKey,
+				Err:  fmt.Errorf("must be at least 1 but got %d", parallelism),
+			}
+		}
+	}
+	return NewPool(RunContext(), int(parallelism), mode, logger), nil
+}
+
+// Go submits a task to the pool, waiting until fewer than the pool's parallelism
+// tasks are running. If the pool has been canceled, the task is not run. Tasks
+// must not submit other tasks to the same pool, as that may deadlock.
+func (p *Pool) Go(name string, task Task) {
+	p.mutex.Lock()
+	index := len(p.results)
+	p.results = append(p.results, nil)
+	p.mutex.Unlock()
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-p.ctx.Done():
+		p.skip()
+		return
+	}
+	if p.ctx.Err() != nil {
+		<-p.slots
+		p.skip()
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer func() {
+			<-p.slots
+			p.wg.Done()
+		}()
+		result, err := task(p.ctx, p.logger.WithPrefix(fmt.Sprintf("[%s] ", name)))
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		p.results[index] = result
+		if err == nil {
+			return
+		}
+		taskErr := &TaskError{Index: index, Name: name, Err: err}
+		p.errs = append(p.errs, taskErr)
+		if p.mode == FailFast && p.failure == nil {
+			p.failure = taskErr
+			p.cancel()
+		}
+	}()
+}
+
+func (p *Pool) skip() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.skipped = true
+}
+
+// Wait waits for the submitted tasks to finish and returns their results in the
+// order they were submitted, with nil for tasks which failed or were not run. In
+// FailFast mode, the error is the TaskError of the first task to fail. In CollectAll
+// mode, it is a TaskErrors of every failed task. If the pool's context was canceled
+// before all tasks ran, its error is returned.
+func (p *Pool) Wait() ([]interface{}, error) {
+	p.wg.Wait()
+	defer p.cancel()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.failure != nil {
+		return p.results, p.failure
+	}
+	if len(p.errs) > 0 {
+		errs := make(TaskErrors, len(p.errs))
+		copy(errs, p.errs)
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Index < errs[j].Index
+		})
+		return p.results, errs
+	}
+	if p.skipped {
+		return p.results, p.ctx.Err()
+	}
+	return p.results, nil
+}
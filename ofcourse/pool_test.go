@@ -0,0 +1,195 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PoolOrder(t *testing.T) {
+	var running, maxRunning int32
+	pool := NewPool(context.Background(), 3, FailFast, NewLogger(SilentLevel))
+	for i := 0; i < 20; i++ {
+		i := i
+		pool.Go(fmt.Sprintf("task-%d", i), func(ctx context.Context, logger *Logger) (interface{}, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(20-i) * time.Millisecond / 4)
+			return i * i, nil
+		})
+	}
+	results, err := pool.Wait()
+	assert.Nil(t, err)
+	assert.Len(t, results, 20)
+	for i, result := range results {
+		assert.Equal(t, i*i, result)
+	}
+	assert.True(t, maxRunning <= 3, "ran %d tasks at once", maxRunning)
+}
+
+func Test_PoolFailFast(t *testing.T) {
+	pool := NewPool(context.Background(), 2, FailFast, NewLogger(SilentLevel))
+	pool.Go("ok", func(ctx context.Context, logger *Logger) (interface{}, error) {
+		return "ok", nil
+	})
+	pool.Go("blocked", func(ctx context.Context, logger *Logger) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	pool.Go("broken", func(ctx context.Context, logger *Logger) (interface{}, error) {
+		return nil, errors.New("connection refused")
+	})
+	var ran int32
+	for i := 0; i < 10; i++ {
+		pool.Go("skipped", func(ctx context.Context, logger *Logger) (interface{}, error) {
+			atomic.AddInt32(&ran, 1)
+			return nil, nil
+		})
+	}
+	results, err := pool.Wait()
+	assert.EqualError(t, err, "broken: connection refused")
+	var taskErr *TaskError
+	assert.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, 2, taskErr.Index)
+	assert.Equal(t, "ok", results[0])
+	assert.Len(t, results, 13)
+	assert.Equal(t, int32(0), ran)
+}
+
+func Test_PoolCollectAll(t *testing.T) {
+	pool := NewPool(context.Background(), 4, CollectAll, NewLogger(SilentLevel))
+	for i := 0; i < 8; i++ {
+		i := i
+		pool.Go(fmt.Sprintf("page-%d", i), func(ctx context.Context, logger *Logger) (interface{}, error) {
+			if i%3 == 0 {
+				time.Sleep(time.Duration(8-i) * time.Millisecond)
+				return nil, fmt.Errorf("status %d", 500+i)
+			}
+			return i, nil
+		})
+	}
+	results, err := pool.Wait()
+	assert.Equal(t, []interface{}{nil, 1, 2, nil, 4, 5, nil, 7}, results)
+	assert.EqualError(t, err, "3 of the tasks failed: page-0: status 500; page-3: status 503; "+
+		"page-6: status 506")
+	errs, ok := err.(TaskErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 3)
+}
+
+func Test_PoolCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewPool(ctx, 1, CollectAll, NewLogger(SilentLevel))
+	started := make(chan struct{})
+	pool.Go("first", func(ctx context.Context, logger *Logger) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return "stopped", nil
+	})
+	<-started
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	// Blocks until the first task finishes after cancellation, then is skipped.
+	pool.Go("second", func(ctx context.Context, logger *Logger) (interface{}, error) {
+		t.Error("task ran after cancellation")
+		return nil, nil
+	})
+	results, err := pool.Wait()
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []interface{}{"stopped", nil}, results)
+}
+
+func Test_PoolCancelAfterTasksRan(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewPool(ctx, 2, CollectAll, NewLogger(SilentLevel))
+	pool.Go("only", func(ctx context.Context, logger *Logger) (interface{}, error) {
+		return "done", nil
+	})
+	// Every task was run, so canceling afterwards is not an error.
+	pool.wg.Wait()
+	cancel()
+	results, err := pool.Wait()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"done"}, results)
+}
+
+func Test_PoolLogPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{Level: infoLevel, Output: &buf}
+	pool := NewPool(context.Background(), 4, FailFast, logger)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		pool.Go(name, func(ctx context.Context, logger *Logger) (interface{}, error) {
+			for i := 0; i < 10; i++ {
+				logger.Infof("copied %d%%", i*10)
+			}
+			return nil, nil
+		})
+	}
+	_, err := pool.Wait()
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 30)
+	sort.Strings(lines)
+	assert.Equal(t, "\033[1;32m[a.txt] copied 0%\033[0m", lines[0])
+	assert.Equal(t, "\033[1;32m[c.txt] copied 90%\033[0m", lines[29])
+	assert.Equal(t, "", logger.Prefix)
+}
+
+func Test_PoolFromSource(t *testing.T) {
+	tests := []struct {
+		source      Source
+		parallelism int
+		err         string
+	}{
+		{Source{}, DefaultParallelism, ""},
+		{Source{"parallelism": 16}, 16, ""},
+		{Source{"parallelism": 2.0}, 2, ""},
+		{Source{"parallelism": 0}, 0, `source key "parallelism": must be at least 1 but got 0`},
+		{Source{"parallelism": "many"}, 0, `source key "parallelism": expected integer but got string`},
+	}
+	for _, test := range tests {
+		pool, err := PoolFromSource(test.source, FailFast, NewLogger(SilentLevel))
+		if test.err == "" {
+			assert.Nil(t, err)
+			assert.Equal(t, test.parallelism, cap(pool.slots))
+		} else {
+			assert.EqualError(t, err, test.err)
+		}
+	}
+}
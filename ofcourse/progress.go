@@ -0,0 +1,162 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultProgressInterval is the minimum time between progress messages.
+const DefaultProgressInterval = 10 * time.Second
+
+// Progress logs the progress of a transfer at info level, such as
+// "app.tgz: 15.0 MiB of 100.0 MiB (15%), 2.5 MiB/s, ETA 34s". Messages are
+// logged as separate lines at most once per Interval, since the Concourse UI
+// does not redraw lines. It is safe for concurrent use.
+type Progress struct {
+	// Name identifies the transfer in messages.
+	Name string
+	// Total is the expected number of bytes, or zero if it is not known, in
+	// which case no percentage or ETA is logged.
+	Total int64
+	// Interval is the minimum time between messages, defaulting to
+	// DefaultProgressInterval if zero.
+	Interval time.Duration
+	// Logger is where messages are logged.
+	Logger *Logger
+	// Clock is used to measure the rate, defaulting to SystemClock if nil.
+	Clock Clock
+
+	mutex       sync.Mutex
+	started     bool
+	start       time.Time
+	last        time.Time
+	transferred int64
+}
+
+// NewProgress returns a Progress for a transfer of total bytes, which may be zero
+// if unknown, such as when an HTTP response has no Content-Length.
+func NewProgress(name string, total int64, logger *Logger) *Progress {
+	return &Progress{Name: name, Total: total, Logger: logger}
+}
+
+// Reader returns a reader which reports the bytes read from r.
+func (p *Progress) Reader(r io.Reader) io.Reader {
+	return &progressReader{r, p}
+}
+
+// Writer returns a writer which reports the bytes written to w.
+func (p *Progress) Writer(w io.Writer) io.Writer {
+	return &progressWriter{w, p}
+}
+
+// Add reports n more bytes transferred, logging a message if Interval has
+// passed since the last one.
+func (p *Progress) Add(n int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	now := p.now()
+	p.transferred += n
+	interval := p.Interval
+	if interval == 0 {
+		interval = DefaultProgressInterval
+	}
+	if now.Sub(p.last) >= interval {
+		p.last = now
+		p.Logger.Infof("%s", p.message(now))
+	}
+}
+
+// Done logs the total bytes transferred, the time taken, and the average rate.
+func (p *Progress) Done() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	now := p.now()
+	elapsed := now.Sub(p.start)
+	message := fmt.Sprintf("%s: %s in %s", p.Name, FormatBytes(p.transferred),
+		elapsed.Round(time.Second))
+	if rate, ok := p.rate(now); ok {
+		message += fmt.Sprintf(", %s/s", FormatBytes(int64(rate)))
+	}
+	p.Logger.Infof("%s", message)
+}
+
+// now returns the current time, starting the transfer's clock if needed.
+func (p *Progress) now() time.Time {
+	now := clockOrSystem(p.Clock).Now()
+	if !p.started {
+		p.started = true
+		p.start = now
+		p.last = now
+	}
+	return now
+}
+
+func (p *Progress) rate(now time.Time) (float64, bool) {
+	elapsed := now.Sub(p.start).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(p.transferred) / elapsed, true
+}
+
+func (p *Progress) message(now time.Time) string {
+	message := fmt.Sprintf("%s: %s", p.Name, FormatBytes(p.transferred))
+	if p.Total > 0 {
+		message += fmt.Sprintf(" of %s (%d%%)", FormatBytes(p.Total),
+			p.transferred*100/p.Total)
+	}
+	rate, ok := p.rate(now)
+	if !ok {
+		return message
+	}
+	message += fmt.Sprintf(", %s/s", FormatBytes(int64(rate)))
+	if p.Total > 0 && rate > 0 && p.transferred < p.Total {
+		eta := time.Duration(float64(p.Total-p.transferred) / rate * float64(time.Second))
+		message += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+	}
+	return message
+}
+
+type progressReader struct {
+	reader   io.Reader
+	progress *Progress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.progress.Add(int64(n))
+	return n, err
+}
+
+type progressWriter struct {
+	writer   io.Writer
+	progress *Progress
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.progress.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,101 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Progress(t *testing.T) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	progress := NewProgress("app.tgz", 100*1024*1024, &Logger{Level: infoLevel, Output: &buf})
+	progress.Clock = ClockFunc(func() time.Time { return now })
+
+	// Each step transfers 5 MiB in 4 seconds, so a message is logged every third step.
+	for i := 0; i < 8; i++ {
+		progress.Add(5 * 1024 * 1024)
+		now = now.Add(4 * time.Second)
+	}
+	progress.Add(0)
+	progress.Done()
+
+	expected := []string{
+		"app.tgz: 20.0 MiB of 100.0 MiB (20%), 1.7 MiB/s, ETA 48s",
+		"app.tgz: 35.0 MiB of 100.0 MiB (35%), 1.5 MiB/s, ETA 45s",
+		"app.tgz: 40.0 MiB in 32s, 1.2 MiB/s",
+	}
+	assert.Equal(t, expected, logLines(buf.String()))
+}
+
+func Test_ProgressReaderWriter(t *testing.T) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	clock := ClockFunc(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	})
+	var buf bytes.Buffer
+	logger := &Logger{Level: infoLevel, Output: &buf}
+
+	progress := NewProgress("download", 0, logger)
+	progress.Clock = clock
+	progress.Interval = time.Second
+	data, err := ioutil.ReadAll(progress.Reader(strings.NewReader(strings.Repeat("x", 2048))))
+	assert.Nil(t, err)
+	assert.Len(t, data, 2048)
+	progress.Done()
+	lines := logLines(buf.String())
+	assert.True(t, len(lines) > 1)
+	assert.True(t, strings.HasPrefix(lines[len(lines)-1], "download: 2.0 KiB in "))
+	for _, line := range lines[:len(lines)-1] {
+		assert.NotContains(t, line, "ETA")
+		assert.NotContains(t, line, "%")
+	}
+
+	buf.Reset()
+	var out bytes.Buffer
+	progress = NewProgress("upload", 8, logger)
+	progress.Clock = clock
+	progress.Interval = time.Second
+	writer := progress.Writer(&out)
+	for i := 0; i < 2; i++ {
+		n, err := writer.Write([]byte("data"))
+		assert.Nil(t, err)
+		assert.Equal(t, 4, n)
+	}
+	assert.Equal(t, "datadata", out.String())
+	assert.Equal(t, []string{"upload: 8 B of 8 B (100%), 8 B/s"}, logLines(buf.String()))
+}
+
+func logLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimPrefix(line, "\033[1;32m")
+		lines = append(lines, strings.TrimSuffix(line, "\033[0m"))
+	}
+	return lines
+}
@@ -0,0 +1,250 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// PrototypeInterfaceVersion is the version of the prototype interface implemented
+// by RunPrototype.
+const PrototypeInterfaceVersion = "1.0"
+
+// Object is the configuration sent to a prototype message, or an object in its
+// response.
+type Object map[string]interface{}
+
+// UnmarshalJSON decodes numbers in the object as json.Number, so that large
+// integers are not converted to float64 and lose precision.
+func (o *Object) UnmarshalJSON(data []byte) error {
+	object, err := unmarshalConfig(data)
+	if err != nil {
+		return err
+	}
+	*o = object
+	return nil
+}
+
+// PrototypeInfo is the response to the `info` message of a prototype.
+type PrototypeInfo struct {
+	InterfaceVersion string   `json:"interface_version"`
+	Icon             string   `json:"icon,omitempty"`
+	Messages         []string `json:"messages"`
+}
+
+// PrototypeRequest is the input to a prototype message.
+type PrototypeRequest struct {
+	Object Object `json:"object"`
+	// ResponsePath is the file where responses are written as JSON lines. If it
+	// is empty, they are written to stdout.
+	ResponsePath string `json:"response_path,omitempty"`
+	// Dir is the directory holding the message's artifacts, which is the
+	// second command line argument, or the working directory if not given.
+	Dir string `json:"-"`
+}
+
+// PrototypeResponse is one response to a prototype message.
+type PrototypeResponse struct {
+	Object   Object   `json:"object"`
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// Responses streams the responses to a prototype message, one JSON object per line.
+type Responses struct {
+	encoder *json.Encoder
+}
+
+// Send writes one response.
+func (r *Responses) Send(object Object, metadata Metadata) error {
+	if object == nil {
+		object = Object{}
+	}
+	return r.encoder.Encode(PrototypeResponse{Object: object, Metadata: metadata})
+}
+
+// Message handles a prototype message, sending any number of responses.
+type Message func(request *PrototypeRequest, responses *Responses, env Environment,
+	logger *Logger) error
+
+// Prototype implements the Concourse prototype protocol from RFC 37, in which
+// the `info` message describes the prototype and other messages are named
+// actions which stream responses as JSON lines.
+type Prototype struct {
+	// Icon is an icon name for the UI, such as "mdi:github".
+	Icon string
+	// Messages are the actions supported by the prototype, by name.
+	Messages map[string]Message
+}
+
+// Info returns the response to the `info` message.
+func (p *Prototype) Info() PrototypeInfo {
+	messages := make([]string, 0, len(p.Messages))
+	for name := range p.Messages {
+		messages = append(messages, name)
+	}
+	sort.Strings(messages)
+	return PrototypeInfo{
+		InterfaceVersion: PrototypeInterfaceVersion,
+		Icon:             p.Icon,
+		Messages:         messages,
+	}
+}
+
+func (p *Prototype) run(message, dir string, input []byte, stdout io.Writer) error {
+	var request PrototypeRequest
+	if len(bytes.TrimSpace(input)) > 0 {
+		err := json.Unmarshal(input, &request)
+		if err != nil {
+			return err
+		}
+	}
+	request.Dir = dir
+
+	output := stdout
+	if request.ResponsePath != "" {
+		file, err := os.Create(request.ResponsePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	if message == "info" {
+		return json.NewEncoder(output).Encode(p.Info())
+	}
+	handler, ok := p.Messages[message]
+	if !ok {
+		return fmt.Errorf("unknown message %q", message)
+	}
+
+	logger := NewLogger("info")
+	if logLevel, ok := request.Object["log_level"].(string); ok {
+		logger = NewLogger(logLevel)
+	}
+	responses := &Responses{encoder: json.NewEncoder(output)}
+	return handler(&request, responses, NewEnvironment(), logger)
+}
+
+// RunPrototype takes a prototype as its input. The Main function of the prototype's
+// executable should create the prototype and pass it to this function. The message
+// is given by the first command line argument, or by the name of the executable,
+// and an optional second argument is the directory of the message's artifacts.
+// The request is read from stdin.
+func RunPrototype(prototype *Prototype) {
+	message := filepath.Base(os.Args[0])
+	if len(os.Args) > 1 {
+		message = os.Args[1]
+	}
+	dir := "."
+	if len(os.Args) > 2 {
+		dir = os.Args[2]
+	}
+
+	input, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		internalLogger.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	endRun := startRun()
+	err = prototype.run(message, dir, input, os.Stdout)
+	endRun()
+	if err != nil {
+		internalLogger.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+// ResourcePrototype adapts a Resource to a prototype with the messages `check`,
+// `get`, and `put`. The object of each request has the same `source`, `version`,
+// and `params` keys as the input of the classic `check`, `in`, and `out` commands,
+// and the request's Dir is the output directory of `get` and the input directory
+// of `put`. Each version found by `check` is sent as a response, and `get` and
+// `put` send one response with the version and metadata. The resource's Config is
+// applied just as with Check, In, and Out.
+func ResourcePrototype(resource Resource, icon string) *Prototype {
+	return &Prototype{
+		Icon: icon,
+		Messages: map[string]Message{
+			"check": func(request *PrototypeRequest, responses *Responses, env Environment,
+				logger *Logger) error {
+				return adaptResource(request, responses, func(input []byte) ([]byte, error) {
+					return check(resource, input)
+				}, true)
+			},
+			"get": func(request *PrototypeRequest, responses *Responses, env Environment,
+				logger *Logger) error {
+				return adaptResource(request, responses, func(input []byte) ([]byte, error) {
+					return in(resource, request.Dir, input)
+				}, false)
+			},
+			"put": func(request *PrototypeRequest, responses *Responses, env Environment,
+				logger *Logger) error {
+				return adaptResource(request, responses, func(input []byte) ([]byte, error) {
+					return out(resource, request.Dir, input)
+				}, false)
+			},
+		},
+	}
+}
+
+func adaptResource(request *PrototypeRequest, responses *Responses,
+	dispatch func([]byte) ([]byte, error), versions bool) error {
+	input, err := json.Marshal(request.Object)
+	if err != nil {
+		return err
+	}
+	output, err := dispatch(input)
+	if err != nil {
+		return err
+	}
+	if versions {
+		var checked []Object
+		err = json.Unmarshal(output, &checked)
+		if err != nil {
+			return err
+		}
+		for _, version := range checked {
+			err = responses.Send(version, nil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var result struct {
+		Version  Object   `json:"version"`
+		Metadata Metadata `json:"metadata"`
+	}
+	err = json.Unmarshal(output, &result)
+	if err != nil {
+		return err
+	}
+	return responses.Send(result.Version, result.Metadata)
+}
@@ -0,0 +1,158 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeResponses(t *testing.T, output string) []PrototypeResponse {
+	var responses []PrototypeResponse
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var response PrototypeResponse
+		assert.Nil(t, json.Unmarshal([]byte(line), &response), line)
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func Test_PrototypeInfo(t *testing.T) {
+	prototype := ResourcePrototype(&resource{}, "mdi:github")
+	var stdout bytes.Buffer
+	err := prototype.run("info", ".", []byte(`{"object": {}}`), &stdout)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"interface_version":"1.0","icon":"mdi:github","messages":["check","get","put"]}`+"\n",
+		stdout.String())
+
+	stdout.Reset()
+	err = prototype.run("info", ".", nil, &stdout)
+	assert.Nil(t, err)
+
+	err = prototype.run("destroy", ".", []byte(`{"object": {}}`), &stdout)
+	assert.EqualError(t, err, `unknown message "destroy"`)
+}
+
+func Test_PrototypeMessage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	prototype := &Prototype{
+		Messages: map[string]Message{
+			"list": func(request *PrototypeRequest, responses *Responses, env Environment,
+				logger *Logger) error {
+				count, _ := request.Object["count"].(json.Number).Int64()
+				for i := 0; i < int(count); i++ {
+					err := responses.Send(Object{"index": i}, Metadata{{Name: "dir", Value: request.Dir}})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+	}
+	responsePath := filepath.Join(dir, "response")
+	var stdout bytes.Buffer
+	input := `{"object": {"count": 3, "log_level": "silent"}, "response_path": "` + responsePath + `"}`
+	err = prototype.run("list", "artifacts", []byte(input), &stdout)
+	assert.Nil(t, err)
+	assert.Equal(t, "", stdout.String())
+
+	output, err := ioutil.ReadFile(responsePath)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"object":{"index":0},"metadata":[{"name":"dir","value":"artifacts"}]}
+{"object":{"index":1},"metadata":[{"name":"dir","value":"artifacts"}]}
+{"object":{"index":2},"metadata":[{"name":"dir","value":"artifacts"}]}
+`, string(output))
+}
+
+func Test_ResourcePrototype(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ofcourse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	versions := []Version{{"tag": "v1.0.0"}, {"tag": "v1.1.0"}, {"tag": "nightly"}}
+	prototype := ResourcePrototype(&filteredResource{versions: versions}, "")
+	var stdout bytes.Buffer
+	input := `{"object": {"source": {"log_level": "silent", "version_regex": {"tag": "^v"}}, "version": null}}`
+	err = prototype.run("check", dir, []byte(input), &stdout)
+	assert.Nil(t, err)
+	assert.Equal(t, []PrototypeResponse{
+		{Object: Object{"tag": "v1.0.0"}},
+		{Object: Object{"tag": "v1.1.0"}},
+	}, decodeResponses(t, stdout.String()))
+
+	prototype = ResourcePrototype(&resultFilesResource{version: Version{"ref": "abc123"}}, "")
+	stdout.Reset()
+	input = `{"object": {"source": {"log_level": "silent"}, "version": {"ref": "abc123"}, "params": {}}}`
+	err = prototype.run("get", dir, []byte(input), &stdout)
+	assert.Nil(t, err)
+	assert.Equal(t, []PrototypeResponse{
+		{Object: Object{"ref": "abc123"}, Metadata: Metadata{{Name: "size", Value: "1 KiB"}}},
+	}, decodeResponses(t, stdout.String()))
+	ref, err := ioutil.ReadFile(filepath.Join(dir, "ref"))
+	assert.Nil(t, err)
+	assert.Equal(t, "abc123", string(ref))
+
+	prototype = ResourcePrototype(&dryRunResource{dryRun: true}, "")
+	stdout.Reset()
+	input = `{"object": {"source": {"log_level": "silent"}, "params": {"dry_run": true}}}`
+	err = prototype.run("put", dir, []byte(input), &stdout)
+	assert.Nil(t, err)
+	assert.Equal(t, []PrototypeResponse{
+		{Object: Object{"number": "1.2.3"}, Metadata: Metadata{{Name: "dry_run", Value: "true"}}},
+	}, decodeResponses(t, stdout.String()))
+
+	err = prototype.run("put", dir, []byte(`{"object": {"source": []}}`), &stdout)
+	assert.NotNil(t, err)
+}
+
+type sourceResource struct {
+	emptyResource
+	source Source
+}
+
+func (r *sourceResource) Check(source Source, version Version, env Environment,
+	logger *Logger) ([]Version, error) {
+	r.source = source
+	return []Version{}, nil
+}
+
+func Test_ResourcePrototypeLargeNumbers(t *testing.T) {
+	resource := &sourceResource{}
+	prototype := ResourcePrototype(resource, "")
+	var stdout bytes.Buffer
+	input := `{"object": {"source": {"log_level": "silent", "id": 9007199254740993}}}`
+	err := prototype.run("check", ".", []byte(input), &stdout)
+	assert.Nil(t, err)
+	id, err := resource.source.Int("id")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+}
@@ -0,0 +1,320 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// RetryAttemptsKey is the standard source key for the number of attempts
+	// made by a Retry, including the first.
+	RetryAttemptsKey = "retry_attempts"
+	// RetryMaxDelayKey is the standard source key for the longest delay between
+	// attempts made by a Retry.
+	RetryMaxDelayKey = "retry_max_delay"
+	// DefaultRetryAttempts is the number of attempts if the source does not
+	// set RetryAttemptsKey.
+	DefaultRetryAttempts = 5
+	// DefaultRetryBaseDelay is the delay before the first retry, which doubles
+	// with each further retry.
+	DefaultRetryBaseDelay = time.Second
+	// DefaultRetryMaxDelay is the longest delay between attempts if the source
+	// does not set RetryMaxDelayKey.
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// HTTPStatusError is returned by the round-tripper of Retry for a response with
+// a status that may be retried.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+	// RetryAfter is the delay requested by the response's Retry-After header,
+	// or zero if there was none.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.Status
+}
+
+// Category returns TransientError for the statuses 408, 429, 500, 502, 503, and
+// 504, and PermanentError for others.
+func (e *HTTPStatusError) Category() ErrorCategory {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return TransientError
+	}
+	return PermanentError
+}
+
+// RetryDelay returns the delay requested by the server.
+func (e *HTTPStatusError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// Retry retries operations which fail with transient errors, waiting between
+// attempts with exponential backoff and jitter.
+type Retry struct {
+	// Attempts is the number of attempts, including the first.
+	Attempts int
+	// BaseDelay is the delay before the first retry, which doubles with each
+	// further retry.
+	BaseDelay time.Duration
+	// MaxDelay is the longest delay between attempts. It also limits delays
+	// requested by errors with a `RetryDelay() time.Duration` method.
+	MaxDelay time.Duration
+	// Retryable decides whether an error may be retried, defaulting to
+	// IsTransient if nil.
+	Retryable func(error) bool
+	// Logger logs a warning for each retry.
+	Logger *Logger
+
+	// sleep waits for d or until ctx is done, replaceable in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetry returns a Retry with the default settings.
+func NewRetry(logger *Logger) *Retry {
+	return &Retry{
+		Attempts:  DefaultRetryAttempts,
+		BaseDelay: DefaultRetryBaseDelay,
+		MaxDelay:  DefaultRetryMaxDelay,
+		Logger:    logger,
+	}
+}
+
+// RetryFromSource returns a Retry with its attempts and maximum delay set by the
+// standard source keys `retry_attempts` and `retry_max_delay`.
+func RetryFromSource(source Source, logger *Logger) (*Retry, error) {
+	retry := NewRetry(logger)
+	if _, ok := source[RetryAttemptsKey]; ok {
+		attempts, err := source.Int(RetryAttemptsKey)
+		if err != nil {
+			return nil, err
+		}
+		if attempts < 1 {
+			return nil, &KeyError{
+				Kind: "source",
+				Key:  RetryAttemptsKey,
+				Err:  fmt.Errorf("must be at least 1 but got %d", attempts),
+			}
+		}
+		retry.Attempts = int(attempts)
+	}
+	if _, ok := source[RetryMaxDelayKey]; ok {
+		maxDelay, err := source.Duration(RetryMaxDelayKey)
+		if err != nil {
+			return nil, err
+		}
+		if maxDelay <= 0 {
+			return nil, &KeyError{
+				Kind: "source",
+				Key:  RetryMaxDelayKey,
+				Err:  fmt.Errorf("must be positive but got %s", maxDelay),
+			}
+		}
+		retry.MaxDelay = maxDelay
+		if retry.BaseDelay > maxDelay {
+			retry.BaseDelay = maxDelay
+		}
+	}
+	return retry, nil
+}
+
+// Do calls f until it succeeds, returns an error which is not retryable, or has
+// been called Attempts times, returning its last error. If ctx is done while
+// waiting to retry, the context's error is returned. The name describes the
+// operation in log messages.
+func (r *Retry) Do(ctx context.Context, name string, f func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := f(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= r.Attempts || !r.retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		delay := r.delay(attempt, err)
+		r.Logger.Warnf("%s failed (attempt %d of %d): %s, retrying in %s",
+			name, attempt, r.Attempts, err, delay)
+		if err := r.wait(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Retry) retryable(err error) bool {
+	if r.Retryable != nil {
+		return r.Retryable(err)
+	}
+	return IsTransient(err)
+}
+
+// delay returns the time to wait after the given attempt failed with err.
+func (r *Retry) delay(attempt int, err error) time.Duration {
+	var requested interface{ RetryDelay() time.Duration }
+	if errors.As(err, &requested) && requested.RetryDelay() > 0 {
+		delay := requested.RetryDelay()
+		if r.MaxDelay > 0 && delay > r.MaxDelay {
+			delay = r.MaxDelay
+		}
+		return delay
+	}
+	delay := r.BaseDelay
+	for i := 1; i < attempt && (r.MaxDelay <= 0 || delay < r.MaxDelay); i++ {
+		delay *= 2
+	}
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	// Equal jitter: wait at least half of the delay, so that clients which
+	// failed together spread out without retrying immediately.
+	half := delay / 2
+	if half > 0 {
+		delay = half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+	return delay
+}
+
+func (r *Retry) wait(ctx context.Context, delay time.Duration) error {
+	if r.sleep != nil {
+		return r.sleep(ctx, delay)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Transport returns an http.RoundTripper which retries requests with base,
+// defaulting to http.DefaultTransport if nil. Requests are retried if they fail
+// with a retryable error or a response with a transient HTTPStatusError status,
+// waiting as long as a Retry-After header asks. Only requests with idempotent
+// methods, or an Idempotency-Key header, and a body which can be replayed are
+// retried. After the last attempt, the last response is returned as usual.
+func (r *Retry) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, retry: r}
+}
+
+type retryTransport struct {
+	base  http.RoundTripper
+	retry *Retry
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !replayable(req) {
+		return t.base.RoundTrip(req)
+	}
+	var resp *http.Response
+	name := fmt.Sprintf("%s %s", req.Method, RedactURL(req.URL))
+	err := t.retry.Do(req.Context(), name, func(ctx context.Context) error {
+		if resp != nil {
+			discardBody(resp)
+			resp = nil
+		}
+		attemptReq := req
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return Permanent(err)
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+		var err error
+		resp, err = t.base.RoundTrip(attemptReq)
+		if err != nil {
+			return err
+		}
+		return statusError(resp)
+	})
+	if resp != nil && req.Context().Err() != nil {
+		discardBody(resp)
+		resp = nil
+	}
+	if resp != nil {
+		return resp, nil
+	}
+	return nil, err
+}
+
+func replayable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// statusError returns an HTTPStatusError if the response has a transient status.
+func statusError(resp *http.Response) error {
+	err := &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	if err.Category() != TransientError {
+		return nil
+	}
+	err.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return err
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+func discardBody(resp *http.Response) {
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+}
@@ -0,0 +1,208 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRetry returns a Retry which records its delays instead of sleeping.
+func testRetry(logger *Logger, delays *[]time.Duration) *Retry {
+	retry := NewRetry(logger)
+	retry.sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return ctx.Err()
+	}
+	return retry
+}
+
+func Test_RetryDo(t *testing.T) {
+	var buf bytes.Buffer
+	var delays []time.Duration
+	retry := testRetry(&Logger{Level: warnLevel, Output: &buf}, &delays)
+	retry.MaxDelay = 3 * time.Second
+
+	calls := 0
+	err := retry.Do(context.Background(), "listing releases", func(ctx context.Context) error {
+		calls++
+		if calls < 4 {
+			return Transient(errors.New("connection reset"))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, calls)
+	assert.Len(t, delays, 3)
+	for i, max := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		assert.True(t, delays[i] >= max/2 && delays[i] <= max, "delay %d is %s", i, delays[i])
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "listing releases failed (attempt 1 of 5): connection reset, retrying in ")
+
+	calls = 0
+	err = retry.Do(context.Background(), "op", func(ctx context.Context) error {
+		calls++
+		return Transient(fmt.Errorf("failure %d", calls))
+	})
+	assert.EqualError(t, err, "failure 5")
+	assert.Equal(t, 5, calls)
+
+	calls = 0
+	err = retry.Do(context.Background(), "op", func(ctx context.Context) error {
+		calls++
+		return errors.New("unauthorized")
+	})
+	assert.EqualError(t, err, "unauthorized")
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	retry.Retryable = func(err error) bool { return err.Error() == "unauthorized" }
+	err = retry.Do(context.Background(), "op", func(ctx context.Context) error {
+		calls++
+		return errors.New("unauthorized")
+	})
+	assert.EqualError(t, err, "unauthorized")
+	assert.Equal(t, 5, calls)
+}
+
+func Test_RetryDoContext(t *testing.T) {
+	retry := NewRetry(NewLogger(SilentLevel))
+	retry.BaseDelay = time.Hour
+	retry.MaxDelay = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	calls := 0
+	err := retry.Do(ctx, "op", func(ctx context.Context) error {
+		calls++
+		return Transient(errors.New("busy"))
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, calls)
+}
+
+func Test_RetryFromSource(t *testing.T) {
+	tests := []struct {
+		source   Source
+		attempts int
+		maxDelay time.Duration
+		err      string
+	}{
+		{Source{}, DefaultRetryAttempts, DefaultRetryMaxDelay, ""},
+		{Source{"retry_attempts": 2, "retry_max_delay": "5m"}, 2, 5 * time.Minute, ""},
+		{Source{"retry_max_delay": 10}, DefaultRetryAttempts, 10 * time.Second, ""},
+		{Source{"retry_attempts": 0}, 0, 0, `source key "retry_attempts": must be at least 1 but got 0`},
+		{Source{"retry_max_delay": 0}, 0, 0, `source key "retry_max_delay": must be positive but got 0s`},
+		{Source{"retry_max_delay": "-1s"}, 0, 0, `source key "retry_max_delay": must be positive but got -1s`},
+		{Source{"retry_max_delay": true}, 0, 0, `source key "retry_max_delay": expected duration but got bool`},
+	}
+	for _, test := range tests {
+		retry, err := RetryFromSource(test.source, NewLogger(SilentLevel))
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, test.attempts, retry.Attempts)
+		assert.Equal(t, test.maxDelay, retry.MaxDelay)
+	}
+}
+
+func Test_RetryTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			fmt.Fprintf(w, "got %s", body)
+		}
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	retry := testRetry(NewLogger(SilentLevel), &delays)
+	client := &http.Client{Transport: retry.Transport(nil)}
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("data"))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), requests)
+
+	req, err := http.NewRequest("PUT", server.URL, strings.NewReader("data"))
+	assert.Nil(t, err)
+	atomic.StoreInt32(&requests, 0)
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, "got data", string(body))
+	assert.Equal(t, int32(3), requests)
+	assert.Len(t, delays, 2)
+	assert.Equal(t, 7*time.Second, delays[0])
+
+	retry.Attempts = 2
+	atomic.StoreInt32(&requests, 0)
+	resp, err = client.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(2), requests)
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		delay time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{"-1", 0},
+		{"Mon, 01 Oct 2018 12:00:30 GMT", 30 * time.Second},
+		{"Mon, 01 Oct 2018 11:00:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.delay, parseRetryAfter(test.value, now), test.value)
+	}
+}
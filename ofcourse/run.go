@@ -0,0 +1,107 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// abortGracePeriod is how long a resource has to return after the run context is
+// canceled by a signal, before the run's cleanup functions are called and the
+// process exits.
+const abortGracePeriod = 5 * time.Second
+
+var (
+	runMutex   sync.Mutex
+	runContext = context.Background()
+	cleanups   []func()
+)
+
+// RunContext returns the context of the current `check`, `in`, or `out` run. When
+// the resource is run by Check, In, or Out, it is canceled if the process receives
+// SIGINT or SIGTERM, as happens when a build is aborted. Long running work started by
+// a resource should be bound to it so that it stops promptly. Outside of a run, such
+// as in tests, it is context.Background().
+func RunContext() context.Context {
+	runMutex.Lock()
+	defer runMutex.Unlock()
+	return runContext
+}
+
+// OnRunEnd registers f to be called when the current run ends, such as to remove
+// temporary files. If the run is aborted and the resource does not return soon
+// after the run context is canceled, f is called before the process exits.
+// Functions are called in the reverse order of their registration.
+func OnRunEnd(f func()) {
+	runMutex.Lock()
+	defer runMutex.Unlock()
+	cleanups = append(cleanups, f)
+}
+
+func runCleanups() {
+	runMutex.Lock()
+	pending := cleanups
+	cleanups = nil
+	runMutex.Unlock()
+	for i := len(pending) - 1; i >= 0; i-- {
+		pending[i]()
+	}
+}
+
+// startRun sets up the run context and returns a function to end the run.
+func startRun() func() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	runMutex.Lock()
+	runContext = ctx
+	runMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+		timer := time.NewTimer(abortGracePeriod)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			runCleanups()
+			internalLogger.Errorf("aborted")
+			os.Exit(1)
+		}
+	}()
+
+	return func() {
+		close(done)
+		stop()
+		runCleanups()
+		runMutex.Lock()
+		runContext = context.Background()
+		runMutex.Unlock()
+	}
+}
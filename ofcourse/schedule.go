@@ -0,0 +1,263 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultScheduleVersionKey is the version key used by a Schedule if none is given.
+	DefaultScheduleVersionKey = "time"
+)
+
+var (
+	// ErrScheduleTrigger means a Schedule has none of Interval, Cron, or Start and Stop.
+	ErrScheduleTrigger = errors.New("schedule needs an interval, a cron expression, or a start and stop")
+	// ErrScheduleConflict means a Schedule has both Interval and Cron.
+	ErrScheduleConflict = errors.New("schedule may not have both an interval and a cron expression")
+	// ErrScheduleWindow means a Schedule has only one of Start and Stop.
+	ErrScheduleWindow = errors.New("schedule must have both a start and a stop, or neither")
+
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+		"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+		"saturday": time.Saturday,
+	}
+)
+
+// TimeOfDay is a time of day, used for the window of a Schedule.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// ParseTimeOfDay parses a time of day such as "15:04", "3:04 PM", or "3PM".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	normalized := strings.ToUpper(strings.Replace(s, " ", "", -1))
+	for _, layout := range []string{"15:04", "3:04PM", "3PM"} {
+		if t, err := time.Parse(layout, normalized); err == nil {
+			return TimeOfDay{Hour: t.Hour(), Minute: t.Minute()}, nil
+		}
+	}
+	return TimeOfDay{}, fmt.Errorf("invalid time of day %q", s)
+}
+
+func (t TimeOfDay) minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// Schedule generates versions from the time, for resources that trigger jobs
+// periodically. A new version is due when Interval has passed since the previous
+// version, or when Cron has fired since the previous version. If only Start and
+// Stop are given, one version is due each time the window opens.
+type Schedule struct {
+	// Interval is the minimum time between versions.
+	Interval time.Duration
+	// Cron is a cron expression for when versions are due.
+	Cron *Cron
+	// Location is the time zone of Cron, Start, Stop, and Days, defaulting to UTC.
+	Location *time.Location
+	// Start and Stop are the window within which versions may be created. If
+	// Stop is before Start, the window spans midnight.
+	Start *TimeOfDay
+	Stop  *TimeOfDay
+	// Days are the days of the week on which versions may be created. If empty,
+	// every day is allowed.
+	Days []time.Weekday
+	// Clock tells the current time, defaulting to SystemClock.
+	Clock Clock
+	// Key is the version key holding the time, defaulting to DefaultScheduleVersionKey.
+	Key string
+}
+
+// ScheduleFromSource creates a Schedule from the source keys `interval`, `cron`,
+// `location`, `start`, `stop`, and `days`, all of which are optional, though one of
+// `interval`, `cron`, or `start` and `stop` is required. Days are the names of the
+// days of the week, e.g. "Monday".
+func ScheduleFromSource(source Source, clock Clock) (*Schedule, error) {
+	schedule := &Schedule{Clock: clock}
+	var err error
+
+	if _, ok := source["interval"]; ok {
+		if schedule.Interval, err = source.Duration("interval"); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := source["cron"]; ok {
+		expression, err := source.String("cron")
+		if err != nil {
+			return nil, err
+		}
+		if schedule.Cron, err = ParseCron(expression); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := source["location"]; ok {
+		name, err := source.String("location")
+		if err != nil {
+			return nil, err
+		}
+		if schedule.Location, err = time.LoadLocation(name); err != nil {
+			return nil, err
+		}
+	}
+	for key, field := range map[string]**TimeOfDay{"start": &schedule.Start, "stop": &schedule.Stop} {
+		if _, ok := source[key]; !ok {
+			continue
+		}
+		s, err := source.String(key)
+		if err != nil {
+			return nil, err
+		}
+		t, err := ParseTimeOfDay(s)
+		if err != nil {
+			return nil, err
+		}
+		*field = &t
+	}
+	if _, ok := source["days"]; ok {
+		days, err := source.StringSlice("days")
+		if err != nil {
+			return nil, err
+		}
+		for _, day := range days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("invalid day of the week %q", day)
+			}
+			schedule.Days = append(schedule.Days, weekday)
+		}
+	}
+
+	if err := schedule.validate(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *Schedule) validate() error {
+	if (s.Start == nil) != (s.Stop == nil) {
+		return ErrScheduleWindow
+	}
+	if s.Interval > 0 && s.Cron != nil {
+		return ErrScheduleConflict
+	}
+	if s.Interval <= 0 && s.Cron == nil && s.Start == nil {
+		return ErrScheduleTrigger
+	}
+	return nil
+}
+
+// Check implements the semantics of Check for a time based resource. If the current
+// time is outside of the window or days, no new version is due. If version is nil,
+// a version for the current time is returned if it is within the window. Otherwise
+// the previous version is returned, followed by a version for the current time if
+// one is due.
+func (s *Schedule) Check(version Version) ([]Version, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	location := s.Location
+	if location == nil {
+		location = time.UTC
+	}
+	key := s.Key
+	if key == "" {
+		key = DefaultScheduleVersionKey
+	}
+	now := clockOrSystem(s.Clock).Now().In(location)
+	current := Version{key: now.Format(time.RFC3339)}
+
+	versions := []Version{}
+	if version != nil {
+		versions = append(versions, version)
+	}
+	if !s.allowed(now) {
+		return versions, nil
+	}
+	if version == nil {
+		return append(versions, current), nil
+	}
+
+	value, ok := version[key]
+	if !ok {
+		return nil, &KeyError{Kind: "version", Key: key, Err: ErrMissingKey}
+	}
+	previous, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, &KeyError{Kind: "version", Key: key, Err: err}
+	}
+	previous = previous.In(location)
+
+	var due bool
+	switch {
+	case s.Interval > 0:
+		due = !now.Before(previous.Add(s.Interval))
+	case s.Cron != nil:
+		next := s.Cron.Next(previous)
+		due = !next.IsZero() && !now.Before(next)
+	default:
+		due = previous.Before(s.windowStart(now))
+	}
+	if due {
+		versions = append(versions, current)
+	}
+	return versions, nil
+}
+
+func (s *Schedule) allowed(now time.Time) bool {
+	if len(s.Days) > 0 {
+		found := false
+		for _, day := range s.Days {
+			if day == now.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.Start == nil {
+		return true
+	}
+	minutes := TimeOfDay{Hour: now.Hour(), Minute: now.Minute()}.minutes()
+	start, stop := s.Start.minutes(), s.Stop.minutes()
+	if start <= stop {
+		return minutes >= start && minutes < stop
+	}
+	return minutes >= start || minutes < stop
+}
+
+// windowStart returns the time at which the window containing now opened.
+func (s *Schedule) windowStart(now time.Time) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), s.Start.Hour, s.Start.Minute,
+		0, 0, now.Location())
+	if now.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
@@ -0,0 +1,229 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+func Test_ScheduleCheck(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	cron, err := ParseCron("0 */6 * * *")
+	assert.Nil(t, err)
+	start, stop := TimeOfDay{Hour: 9}, TimeOfDay{Hour: 17}
+	lateStart, earlyStop := TimeOfDay{Hour: 22}, TimeOfDay{Hour: 2}
+
+	// Monday, October 1st, 2018.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2018, 10, 1, hour, minute, 0, 0, time.UTC)
+	}
+	v := func(t time.Time) Version {
+		return Version{"time": t.Format(time.RFC3339)}
+	}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		now      time.Time
+		version  Version
+		versions []Version
+	}{
+		{
+			name:     "first check",
+			schedule: Schedule{Interval: time.Hour},
+			now:      monday(12, 0),
+			version:  nil,
+			versions: []Version{v(monday(12, 0))},
+		},
+		{
+			name:     "interval not elapsed",
+			schedule: Schedule{Interval: time.Hour},
+			now:      monday(12, 59),
+			version:  v(monday(12, 0)),
+			versions: []Version{v(monday(12, 0))},
+		},
+		{
+			name:     "interval elapsed",
+			schedule: Schedule{Interval: time.Hour},
+			now:      monday(13, 0),
+			version:  v(monday(12, 0)),
+			versions: []Version{v(monday(12, 0)), v(monday(13, 0))},
+		},
+		{
+			name:     "cron not fired",
+			schedule: Schedule{Cron: cron},
+			now:      monday(11, 59),
+			version:  v(monday(6, 0)),
+			versions: []Version{v(monday(6, 0))},
+		},
+		{
+			name:     "cron fired",
+			schedule: Schedule{Cron: cron},
+			now:      monday(12, 1),
+			version:  v(monday(6, 0)),
+			versions: []Version{v(monday(6, 0)), v(monday(12, 1))},
+		},
+		{
+			name:     "first check outside window",
+			schedule: Schedule{Interval: time.Hour, Start: &start, Stop: &stop},
+			now:      monday(8, 0),
+			version:  nil,
+			versions: []Version{},
+		},
+		{
+			name:     "interval elapsed outside window",
+			schedule: Schedule{Interval: time.Hour, Start: &start, Stop: &stop},
+			now:      monday(17, 0),
+			version:  v(monday(15, 0)),
+			versions: []Version{v(monday(15, 0))},
+		},
+		{
+			name:     "window opened",
+			schedule: Schedule{Start: &start, Stop: &stop},
+			now:      monday(9, 0),
+			version:  v(monday(16, 0).AddDate(0, 0, -1)),
+			versions: []Version{v(monday(16, 0).AddDate(0, 0, -1)), v(monday(9, 0))},
+		},
+		{
+			name:     "window already triggered",
+			schedule: Schedule{Start: &start, Stop: &stop},
+			now:      monday(16, 0),
+			version:  v(monday(9, 0)),
+			versions: []Version{v(monday(9, 0))},
+		},
+		{
+			name:     "window across midnight after midnight",
+			schedule: Schedule{Start: &lateStart, Stop: &earlyStop},
+			now:      monday(1, 0),
+			version:  v(monday(23, 0).AddDate(0, 0, -1)),
+			versions: []Version{v(monday(23, 0).AddDate(0, 0, -1))},
+		},
+		{
+			name:     "window across midnight opened",
+			schedule: Schedule{Start: &lateStart, Stop: &earlyStop},
+			now:      monday(22, 30),
+			version:  v(monday(1, 0)),
+			versions: []Version{v(monday(1, 0)), v(monday(22, 30))},
+		},
+		{
+			name:     "day not allowed",
+			schedule: Schedule{Interval: time.Minute, Days: []time.Weekday{time.Saturday, time.Sunday}},
+			now:      monday(12, 0),
+			version:  v(monday(10, 0)),
+			versions: []Version{v(monday(10, 0))},
+		},
+		{
+			name:     "day allowed",
+			schedule: Schedule{Interval: time.Minute, Days: []time.Weekday{time.Monday}},
+			now:      monday(12, 0),
+			version:  v(monday(10, 0)),
+			versions: []Version{v(monday(10, 0)), v(monday(12, 0))},
+		},
+		{
+			name: "location moves day and window",
+			schedule: Schedule{Interval: time.Hour, Start: &lateStart, Stop: &earlyStop,
+				Days: []time.Weekday{time.Sunday}, Location: newYork},
+			now:      monday(3, 0),
+			version:  nil,
+			versions: []Version{{"time": "2018-09-30T23:00:00-04:00"}},
+		},
+		{
+			name:     "custom key",
+			schedule: Schedule{Interval: time.Hour, Key: "triggered_at"},
+			now:      monday(12, 0),
+			version:  Version{"triggered_at": "2018-10-01T10:00:00Z"},
+			versions: []Version{{"triggered_at": "2018-10-01T10:00:00Z"}, {"triggered_at": "2018-10-01T12:00:00Z"}},
+		},
+	}
+	for _, test := range tests {
+		schedule := test.schedule
+		schedule.Clock = fixedClock(test.now)
+		versions, err := schedule.Check(test.version)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.versions, versions, test.name)
+	}
+}
+
+func Test_ScheduleCheckError(t *testing.T) {
+	clock := fixedClock(time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC))
+	cron, _ := ParseCron("@daily")
+	start := TimeOfDay{Hour: 9}
+
+	_, err := (&Schedule{Clock: clock}).Check(nil)
+	assert.Equal(t, ErrScheduleTrigger, err)
+	_, err = (&Schedule{Interval: time.Hour, Cron: cron, Clock: clock}).Check(nil)
+	assert.Equal(t, ErrScheduleConflict, err)
+	_, err = (&Schedule{Start: &start, Clock: clock}).Check(nil)
+	assert.Equal(t, ErrScheduleWindow, err)
+
+	schedule := &Schedule{Interval: time.Hour, Clock: clock}
+	_, err = schedule.Check(Version{"count": "1"})
+	assert.EqualError(t, err, `version key "time": key is missing`)
+	_, err = schedule.Check(Version{"time": "yesterday"})
+	assert.EqualError(t, err, `version key "time": parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`)
+}
+
+func Test_ScheduleFromSource(t *testing.T) {
+	clock := fixedClock(time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC))
+	schedule, err := ScheduleFromSource(Source{
+		"interval": "1h",
+		"location": "America/New_York",
+		"start":    "9:00 AM",
+		"stop":     "17:30",
+		"days":     []interface{}{"Monday", "friday"},
+	}, clock)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, schedule.Interval)
+	assert.Equal(t, "America/New_York", schedule.Location.String())
+	assert.Equal(t, &TimeOfDay{Hour: 9}, schedule.Start)
+	assert.Equal(t, &TimeOfDay{Hour: 17, Minute: 30}, schedule.Stop)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Friday}, schedule.Days)
+	assert.Equal(t, clock.Now(), schedule.Clock.Now())
+
+	schedule, err = ScheduleFromSource(Source{"cron": "0 12 * * *"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0 12 * * *", schedule.Cron.String())
+
+	tests := []struct {
+		source Source
+		err    string
+	}{
+		{Source{}, ErrScheduleTrigger.Error()},
+		{Source{"interval": "1h", "cron": "@daily"}, ErrScheduleConflict.Error()},
+		{Source{"start": "9AM"}, ErrScheduleWindow.Error()},
+		{Source{"interval": "often"}, `source key "interval": time: invalid duration "often"`},
+		{Source{"cron": "@often"}, `invalid cron expression "@often": expected 5 fields`},
+		{Source{"interval": "1h", "location": "Mars/Olympus_Mons"}, "unknown time zone Mars/Olympus_Mons"},
+		{Source{"start": "25:00", "stop": "1:00"}, `invalid time of day "25:00"`},
+		{Source{"interval": "1h", "days": []interface{}{"Caturday"}}, `invalid day of the week "Caturday"`},
+	}
+	for _, test := range tests {
+		_, err := ScheduleFromSource(test.source, nil)
+		assert.EqualError(t, err, test.err)
+	}
+}
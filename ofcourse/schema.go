@@ -0,0 +1,157 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import "fmt"
+
+// Deprecation describes a key in the source or params that has been renamed
+// or removed. When the old key is found, its value is moved to the new key
+// and a warning is logged.
+type Deprecation struct {
+	// Old is the deprecated key.
+	Old string `json:"old"`
+	// New is the key which replaces Old. If empty, Old has been removed
+	// and its value is dropped.
+	New string `json:"new,omitempty"`
+	// Note tells users when Old will stop working, e.g. "removed in v2.0.0".
+	Note string `json:"note,omitempty"`
+}
+
+// SchemaKey documents a key of the source or params. Keys are not validated, but
+// are described by the resource's `info` command.
+type SchemaKey struct {
+	Name string `json:"name"`
+	// Type is the key's type, such as "string", "bool", "integer", "duration",
+	// "object", or "array".
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Migration restructures a source or params map before it is passed to the
+// resource. It may modify the map in place, and may use the logger to warn
+// about anything it changes.
+type Migration func(config map[string]interface{}, logger *Logger) error
+
+// Schema describes the source or params of a resource and how they have changed
+// over time. Deprecations are applied first, followed by Migrations in order.
+type Schema struct {
+	Keys         []SchemaKey
+	Deprecations []Deprecation
+	Migrations   []Migration
+}
+
+// Config holds optional settings for a resource. A Resource provides them
+// by also implementing Configurable.
+type Config struct {
+	// Name is the name of the resource reported by the `info` command,
+	// defaulting to the last element of the main module's path.
+	Name string
+	// Source is the schema of the resource's source configuration.
+	Source Schema
+	// Params is the schema of the resource's `get` and `put` parameters.
+	Params Schema
+	// VersionFilters enables the standard source keys `ignore_versions`,
+	// `version_regex`, and `initial_version`, which are applied to the
+	// versions returned by Check. See VersionFilter.
+	VersionFilters bool
+	// ResultFiles makes the `in` dispatcher write the Version and Metadata
+	// returned by In to the output directory. See WriteResultFiles.
+	ResultFiles bool
+	// Outputs declares the files In places in its output directory, which
+	// the `in` dispatcher verifies after In returns. See Outputs.
+	Outputs Outputs
+	// DryRun declares that Out supports dry runs, enabling the standard
+	// `dry_run` params and source key. See Params.DryRun.
+	DryRun bool
+}
+
+// Configurable may be implemented by a Resource to enable optional features
+// of this library.
+type Configurable interface {
+	Config() Config
+}
+
+func configOf(resource Resource) Config {
+	if configurable, ok := resource.(Configurable); ok {
+		return configurable.Config()
+	}
+	return Config{}
+}
+
+// Migrate returns a copy of the source with the schema's deprecations and
+// migrations applied, logging a warning for each deprecated key found. This is
+// done automatically before the resource is called, but may also be used
+// in tests to check the migrated source.
+func (s Source) Migrate(schema Schema, logger *Logger) (Source, error) {
+	return schema.migrate("source", s, logger)
+}
+
+// Migrate returns a copy of the params with the schema's deprecations and
+// migrations applied, logging a warning for each deprecated key found. This is
+// done automatically before the resource is called, but may also be used
+// in tests to check the migrated params.
+func (p Params) Migrate(schema Schema, logger *Logger) (Params, error) {
+	return schema.migrate("params", p, logger)
+}
+
+func (s Schema) migrate(kind string, config map[string]interface{},
+	logger *Logger) (map[string]interface{}, error) {
+	migrated := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		migrated[k] = v
+	}
+
+	for _, deprecation := range s.Deprecations {
+		value, ok := migrated[deprecation.Old]
+		if !ok {
+			continue
+		}
+		delete(migrated, deprecation.Old)
+		if deprecation.New == "" {
+			logger.Warnf("%s key %q is deprecated and ignored%s", kind,
+				deprecation.Old, deprecation.note())
+			continue
+		}
+		if _, ok := migrated[deprecation.New]; ok {
+			logger.Warnf("%s key %q is deprecated and ignored because %q is also set%s",
+				kind, deprecation.Old, deprecation.New, deprecation.note())
+			continue
+		}
+		logger.Warnf("%s key %q is deprecated, use %q instead%s", kind,
+			deprecation.Old, deprecation.New, deprecation.note())
+		migrated[deprecation.New] = value
+	}
+
+	for i, migration := range s.Migrations {
+		if err := migration(migrated, logger); err != nil {
+			return nil, fmt.Errorf("%s migration %d failed: %s", kind, i+1, err)
+		}
+	}
+	return migrated, nil
+}
+
+func (d Deprecation) note() string {
+	if d.Note == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", d.Note)
+}
@@ -0,0 +1,131 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = Schema{
+	Deprecations: []Deprecation{
+		{Old: "uri", New: "url", Note: "removed in v2.0.0"},
+		{Old: "verbose"},
+	},
+	Migrations: []Migration{
+		func(config map[string]interface{}, logger *Logger) error {
+			if branch, ok := config["branch"].(string); ok {
+				delete(config, "branch")
+				config["branches"] = []interface{}{branch}
+			}
+			return nil
+		},
+	},
+}
+
+type configurableResource struct {
+	emptyResource
+}
+
+func (r *configurableResource) Config() Config {
+	return Config{Source: testSchema, Params: testSchema}
+}
+
+func (r *configurableResource) Check(source Source, version Version, env Environment,
+	logger *Logger) ([]Version, error) {
+	return []Version{{"url": source["url"].(string)}}, nil
+}
+
+func Test_SourceMigrate(t *testing.T) {
+	tests := []struct {
+		source   Source
+		migrated Source
+		warnings []string
+	}{
+		{
+			Source{},
+			Source{},
+			nil,
+		},
+		{
+			Source{"uri": "a", "log_level": "debug"},
+			Source{"url": "a", "log_level": "debug"},
+			[]string{`source key "uri" is deprecated, use "url" instead (removed in v2.0.0)`},
+		},
+		{
+			Source{"uri": "a", "url": "b"},
+			Source{"url": "b"},
+			[]string{`source key "uri" is deprecated and ignored because "url" is also set (removed in v2.0.0)`},
+		},
+		{
+			Source{"verbose": true, "branch": "main"},
+			Source{"branches": []interface{}{"main"}},
+			[]string{`source key "verbose" is deprecated and ignored`},
+		},
+	}
+	for _, test := range tests {
+		var output bytes.Buffer
+		logger := NewLogger(WarnLevel)
+		logger.Output = &output
+
+		original := Source{}
+		for k, v := range test.source {
+			original[k] = v
+		}
+		migrated, err := test.source.Migrate(testSchema, logger)
+		assert.Nil(t, err)
+		assert.Equal(t, test.migrated, migrated)
+		assert.Equal(t, original, test.source)
+
+		var warnings []string
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			if line != "" {
+				warnings = append(warnings, strings.TrimSuffix(
+					strings.TrimPrefix(line, "\033[1;33m"), "\033[0m"))
+			}
+		}
+		assert.Equal(t, test.warnings, warnings)
+	}
+}
+
+func Test_ParamsMigrateError(t *testing.T) {
+	schema := Schema{
+		Migrations: []Migration{
+			func(config map[string]interface{}, logger *Logger) error {
+				return errors.New("bad params")
+			},
+		},
+	}
+	params, err := Params{"a": "b"}.Migrate(schema, NewLogger(SilentLevel))
+	assert.Nil(t, params)
+	assert.EqualError(t, err, "params migration 1 failed: bad params")
+}
+
+func Test_checkMigrate(t *testing.T) {
+	resource := &configurableResource{}
+	input := []byte(`{"source":{"uri":"a","log_level":"silent"},"version":null}`)
+	output, err := check(resource, input)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`[{"url":"a"}]`), output)
+}
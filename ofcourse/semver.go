@@ -0,0 +1,440 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemVer is a semantic version as described at https://semver.org.
+type SemVer struct {
+	Major uint64
+	Minor uint64
+	Patch uint64
+	// Pre contains the dot separated pre-release identifiers, e.g. ["rc", "1"].
+	Pre []string
+	// Build contains the dot separated build metadata identifiers.
+	Build []string
+}
+
+// ParseSemVer parses a semantic version such as "1.4.2" or "2.0.0-rc.1+build.5".
+// A leading "v" is allowed.
+func ParseSemVer(s string) (SemVer, error) {
+	var v SemVer
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	if i := strings.Index(rest, "+"); i >= 0 {
+		build, err := parseIdentifiers(rest[i+1:], false)
+		if err != nil {
+			return v, fmt.Errorf("invalid semantic version %q: %s", s, err)
+		}
+		v.Build = build
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "-"); i >= 0 {
+		pre, err := parseIdentifiers(rest[i+1:], true)
+		if err != nil {
+			return v, fmt.Errorf("invalid semantic version %q: %s", s, err)
+		}
+		v.Pre = pre
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("invalid semantic version %q: expected major.minor.patch", s)
+	}
+	numbers := make([]uint64, 3)
+	for i, part := range parts {
+		n, err := parseNumericIdentifier(part)
+		if err != nil {
+			return v, fmt.Errorf("invalid semantic version %q: %s", s, err)
+		}
+		numbers[i] = n
+	}
+	v.Major, v.Minor, v.Patch = numbers[0], numbers[1], numbers[2]
+	return v, nil
+}
+
+func parseNumericIdentifier(s string) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty number")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("number %q has a leading zero", s)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+func parseIdentifiers(s string, pre bool) ([]string, error) {
+	identifiers := strings.Split(s, ".")
+	for _, identifier := range identifiers {
+		if identifier == "" {
+			return nil, fmt.Errorf("empty identifier")
+		}
+		for _, c := range identifier {
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return nil, fmt.Errorf("invalid character %q in identifier %q", c, identifier)
+			}
+		}
+		if pre && isNumeric(identifier) {
+			if _, err := parseNumericIdentifier(identifier); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return identifiers, nil
+}
+
+func isNumeric(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// String returns the version in its canonical form, without a leading "v".
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Pre) > 0 {
+		s += "-" + strings.Join(v.Pre, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0, or 1 if v has lower, equal, or higher precedence than o.
+// A pre-release has lower precedence than its release, and build metadata is ignored.
+func (v SemVer) Compare(o SemVer) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePre(v.Pre, o.Pre)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePre(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNumeric, bNumeric := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case aNumeric && bNumeric:
+			an, _ := strconv.ParseUint(a[i], 10, 64)
+			bn, _ := strconv.ParseUint(b[i], 10, 64)
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aNumeric:
+			return -1
+		case bNumeric:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func (v SemVer) sameRelease(o SemVer) bool {
+	return v.Major == o.Major && v.Minor == o.Minor && v.Patch == o.Patch
+}
+
+// Constraint is a set of conditions that a SemVer may satisfy, parsed from an
+// expression such as "~1.4" or ">=2.0.0 <3".
+type Constraint struct {
+	expression string
+	sets       [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version SemVer
+}
+
+// ParseConstraint parses a constraint expression. Conditions separated by spaces or
+// commas must all be satisfied, and groups of conditions separated by "||" are
+// alternatives. Each condition is an operator followed by a version, where the
+// operator is one of "=", "!=", ">", ">=", "<", "<=", "~", or "^", and defaults to
+// "=". Versions may be partial, such as "1" or "1.4", or contain "x" or "*" as
+// wildcards, in which case the condition applies to the whole range they cover:
+//
+//	1.4     >=1.4.0 <1.5.0
+//	~1.4.2  >=1.4.2 <1.5.0
+//	~1      >=1.0.0 <2.0.0
+//	^1.4.2  >=1.4.2 <2.0.0
+//	^0.4.2  >=0.4.2 <0.5.0
+//	>1.4    >=1.5.0
+//	<=1.4   <1.5.0
+//
+// A pre-release version only satisfies a group of conditions if one of them
+// refers to a pre-release of the same major, minor, and patch version, so that
+// ">=2.0.0 <3" does not match "3.0.0-rc.1".
+func ParseConstraint(expression string) (*Constraint, error) {
+	constraint := &Constraint{expression: expression}
+	for _, group := range strings.Split(expression, "||") {
+		fields := strings.FieldsFunc(group, func(r rune) bool {
+			return r == ' ' || r == ',' || r == '\t'
+		})
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid constraint %q: empty condition", expression)
+		}
+		var set []comparator
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			if strings.Trim(field, "=!<>~^") == "" && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+			comparators, err := parseCondition(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid constraint %q: %s", expression, err)
+			}
+			set = append(set, comparators...)
+		}
+		constraint.sets = append(constraint.sets, set)
+	}
+	return constraint, nil
+}
+
+// String returns the expression the constraint was parsed from.
+func (c *Constraint) String() string {
+	return c.expression
+}
+
+// Check reports whether the version satisfies the constraint.
+func (c *Constraint) Check(v SemVer) bool {
+	for _, set := range c.sets {
+		if checkSet(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSet(set []comparator, v SemVer) bool {
+	for _, comparator := range set {
+		if !comparator.check(v) {
+			return false
+		}
+	}
+	if len(v.Pre) == 0 {
+		return true
+	}
+	for _, comparator := range set {
+		if len(comparator.version.Pre) > 0 && comparator.version.sameRelease(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparator) check(v SemVer) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+// partial is a possibly incomplete version in a constraint, where the number of
+// known components is 0 for "*", 1 for "1", 2 for "1.4", and 3 for "1.4.2".
+type partial struct {
+	version SemVer
+	known   int
+}
+
+func parsePartial(s string) (partial, error) {
+	p := partial{}
+	rest := strings.TrimPrefix(s, "v")
+	main := rest
+	if i := strings.IndexAny(rest, "-+"); i >= 0 {
+		main = rest[:i]
+	}
+	parts := strings.Split(main, ".")
+	if len(parts) > 3 || main == "" {
+		return p, fmt.Errorf("invalid version %q", s)
+	}
+	numbers := []*uint64{&p.version.Major, &p.version.Minor, &p.version.Patch}
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := parseNumericIdentifier(part)
+		if err != nil {
+			return p, fmt.Errorf("invalid version %q: %s", s, err)
+		}
+		*numbers[i] = n
+		p.known++
+	}
+	if main != rest {
+		if p.known != 3 {
+			return p, fmt.Errorf("invalid version %q: pre-release requires major.minor.patch", s)
+		}
+		v, err := ParseSemVer(rest)
+		if err != nil {
+			return p, err
+		}
+		p.version = v
+	}
+	return p, nil
+}
+
+// next returns the lowest version above the range covered by the partial version.
+func (p partial) next() SemVer {
+	switch p.known {
+	case 1:
+		return SemVer{Major: p.version.Major + 1}
+	default:
+		return SemVer{Major: p.version.Major, Minor: p.version.Minor + 1}
+	}
+}
+
+func parseCondition(condition string) ([]comparator, error) {
+	op := ""
+	for _, candidate := range []string{"!=", ">=", "<=", "=", ">", "<", "~", "^"} {
+		if strings.HasPrefix(condition, candidate) {
+			op = candidate
+			break
+		}
+	}
+	p, err := parsePartial(condition[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	v := p.version
+	always := []comparator{}
+	never := []comparator{{"<", SemVer{Pre: []string{"0"}}}}
+
+	switch op {
+	case "", "=":
+		switch p.known {
+		case 0:
+			return always, nil
+		case 3:
+			return []comparator{{"=", v}}, nil
+		default:
+			return []comparator{{">=", v}, {"<", p.next()}}, nil
+		}
+	case "!=":
+		if p.known != 3 {
+			return nil, fmt.Errorf("operator != requires major.minor.patch in %q", condition)
+		}
+		return []comparator{{"!=", v}}, nil
+	case "~":
+		switch p.known {
+		case 0:
+			return always, nil
+		case 3:
+			return []comparator{{">=", v}, {"<", SemVer{Major: v.Major, Minor: v.Minor + 1}}}, nil
+		default:
+			return []comparator{{">=", v}, {"<", p.next()}}, nil
+		}
+	case "^":
+		var upper SemVer
+		switch {
+		case p.known == 0:
+			return always, nil
+		case v.Major > 0 || p.known == 1:
+			upper = SemVer{Major: v.Major + 1}
+		case v.Minor > 0 || p.known == 2:
+			upper = SemVer{Minor: v.Minor + 1}
+		default:
+			upper = SemVer{Patch: v.Patch + 1}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	case ">":
+		switch p.known {
+		case 0:
+			return never, nil
+		case 3:
+			return []comparator{{">", v}}, nil
+		default:
+			return []comparator{{">=", p.next()}}, nil
+		}
+	case ">=":
+		if p.known == 0 {
+			return always, nil
+		}
+		return []comparator{{">=", v}}, nil
+	case "<":
+		if p.known == 0 {
+			return never, nil
+		}
+		return []comparator{{"<", v}}, nil
+	case "<=":
+		switch p.known {
+		case 0:
+			return always, nil
+		case 3:
+			return []comparator{{"<=", v}}, nil
+		default:
+			return []comparator{{"<", p.next()}}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid condition %q", condition)
+}
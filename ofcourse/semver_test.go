@@ -0,0 +1,165 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseSemVer(t *testing.T) {
+	tests := []struct {
+		s       string
+		version SemVer
+		err     string
+	}{
+		{"1.4.2", SemVer{Major: 1, Minor: 4, Patch: 2}, ""},
+		{"v0.0.1", SemVer{Patch: 1}, ""},
+		{"2.0.0-rc.1", SemVer{Major: 2, Pre: []string{"rc", "1"}}, ""},
+		{"2.0.0-rc.1+build.5", SemVer{Major: 2, Pre: []string{"rc", "1"}, Build: []string{"build", "5"}}, ""},
+		{"1.0.0+001", SemVer{Major: 1, Build: []string{"001"}}, ""},
+		{"1.0.0-x-y.0a", SemVer{Major: 1, Pre: []string{"x-y", "0a"}}, ""},
+		{"1.4", SemVer{}, `invalid semantic version "1.4": expected major.minor.patch`},
+		{"01.4.2", SemVer{}, `invalid semantic version "01.4.2": number "01" has a leading zero`},
+		{"1.4.x", SemVer{}, `invalid semantic version "1.4.x": invalid number "x"`},
+		{"1.0.0-rc.01", SemVer{}, `invalid semantic version "1.0.0-rc.01": number "01" has a leading zero`},
+		{"1.0.0-rc..1", SemVer{}, `invalid semantic version "1.0.0-rc..1": empty identifier`},
+		{"1.0.0-rc_1", SemVer{}, `invalid semantic version "1.0.0-rc_1": invalid character '_' in identifier "rc_1"`},
+	}
+	for _, test := range tests {
+		v, err := ParseSemVer(test.s)
+		if test.err == "" {
+			assert.Nil(t, err, test.s)
+			assert.Equal(t, test.version, v, test.s)
+		} else {
+			assert.EqualError(t, err, test.err, test.s)
+		}
+	}
+}
+
+func Test_SemVerString(t *testing.T) {
+	for _, s := range []string{"1.4.2", "2.0.0-rc.1", "2.0.0-rc.1+build.5", "0.0.0+b"} {
+		v, err := ParseSemVer(s)
+		assert.Nil(t, err)
+		assert.Equal(t, s, v.String())
+	}
+}
+
+func Test_SemVerCompare(t *testing.T) {
+	// Ordered by precedence, from the example in the semver specification.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := ParseSemVer(ordered[i])
+			b, _ := ParseSemVer(ordered[j])
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, a.Compare(b), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+
+	a, _ := ParseSemVer("1.0.0+a")
+	b, _ := ParseSemVer("1.0.0+b")
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+func Test_Constraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		misses     []string
+	}{
+		{"1.4.2", []string{"1.4.2", "1.4.2+b"}, []string{"1.4.3", "1.4.2-rc.1"}},
+		{"=1.4", []string{"1.4.0", "1.4.9"}, []string{"1.3.9", "1.5.0", "1.4.1-rc.1"}},
+		{"1.x", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.0"}},
+		{"*", []string{"0.0.0", "9.9.9"}, []string{"1.0.0-rc.1"}},
+		{"!=1.4.2", []string{"1.4.1", "1.4.3"}, []string{"1.4.2"}},
+		{"~1.4", []string{"1.4.0", "1.4.7"}, []string{"1.3.0", "1.5.0", "1.5.0-rc.1"}},
+		{"~1.4.2", []string{"1.4.2", "1.4.9"}, []string{"1.4.1", "1.5.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0", "0.9.0"}},
+		{"^1.4.2", []string{"1.4.2", "1.9.0"}, []string{"1.4.1", "2.0.0"}},
+		{"^0.4.2", []string{"0.4.2", "0.4.9"}, []string{"0.5.0", "0.4.1"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4", "0.0.2"}},
+		{"^0.0", []string{"0.0.0", "0.0.9"}, []string{"0.1.0"}},
+		{"^1", []string{"1.0.0", "1.9.9"}, []string{"2.0.0"}},
+		{">1.4", []string{"1.5.0", "2.0.0"}, []string{"1.4.9", "1.4.0"}},
+		{">1.4.2", []string{"1.4.3"}, []string{"1.4.2"}},
+		{">=1.4", []string{"1.4.0", "3.0.0"}, []string{"1.3.9"}},
+		{"<1.4", []string{"1.3.9"}, []string{"1.4.0"}},
+		{"<=1.4", []string{"1.4.9", "1.0.0"}, []string{"1.5.0"}},
+		{"<=1.4.2", []string{"1.4.2"}, []string{"1.4.3"}},
+		{">=2.0.0 <3", []string{"2.0.0", "2.9.9"}, []string{"1.9.9", "3.0.0", "3.0.0-rc.1", "2.1.0-rc.1"}},
+		{">= 2.0.0, < 3", []string{"2.5.0"}, []string{"3.0.0"}},
+		{">=2.0.0-rc.1 <3", []string{"2.0.0-rc.1", "2.0.0-rc.2", "2.0.0"}, []string{"2.0.0-beta.1", "2.1.0-rc.1"}},
+		{"~1.4 || ^3", []string{"1.4.1", "3.2.0"}, []string{"2.0.0", "4.0.0"}},
+		{"<1.0.0-rc.2", []string{"1.0.0-rc.1", "0.9.0"}, []string{"1.0.0-rc.2", "1.0.0"}},
+	}
+	for _, test := range tests {
+		c, err := ParseConstraint(test.constraint)
+		assert.Nil(t, err, test.constraint)
+		assert.Equal(t, test.constraint, c.String())
+		for _, s := range test.matches {
+			v, err := ParseSemVer(s)
+			assert.Nil(t, err)
+			assert.True(t, c.Check(v), "%s should satisfy %s", s, test.constraint)
+		}
+		for _, s := range test.misses {
+			v, err := ParseSemVer(s)
+			assert.Nil(t, err)
+			assert.False(t, c.Check(v), "%s should not satisfy %s", s, test.constraint)
+		}
+	}
+}
+
+func Test_ParseConstraintError(t *testing.T) {
+	tests := []struct {
+		constraint string
+		err        string
+	}{
+		{"", `invalid constraint "": empty condition`},
+		{"1.4 ||", `invalid constraint "1.4 ||": empty condition`},
+		{">=a", `invalid constraint ">=a": invalid version "a": invalid number "a"`},
+		{"!=1.4", `invalid constraint "!=1.4": operator != requires major.minor.patch in "!=1.4"`},
+		{"1.4-rc.1", `invalid constraint "1.4-rc.1": invalid version "1.4-rc.1": pre-release requires major.minor.patch`},
+		{"1.2.3.4", `invalid constraint "1.2.3.4": invalid version "1.2.3.4"`},
+	}
+	for _, test := range tests {
+		_, err := ParseConstraint(test.constraint)
+		assert.EqualError(t, err, test.err)
+	}
+}
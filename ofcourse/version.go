@@ -0,0 +1,207 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ofcourse
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotStruct means a value passed to VersionOf or Version.Decode is not a
+	// struct, or a pointer to one.
+	ErrNotStruct = errors.New("value must be a struct or a pointer to a struct")
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// MissingKeysError is returned by Version.Decode when the version does not
+// contain every key of the struct. This usually means the version was created
+// by an older release of the resource.
+type MissingKeysError struct {
+	Keys []string
+}
+
+func (e *MissingKeysError) Error() string {
+	quoted := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		quoted[i] = strconv.Quote(key)
+	}
+	return fmt.Sprintf("version is missing keys %s, it may be from an older release of the resource",
+		strings.Join(quoted, ", "))
+}
+
+// VersionOf converts a struct to a Version. Fields are mapped to version keys with
+// the `version` struct tag, and fields without the tag are skipped. Supported field
+// types are strings, booleans, integers, and time.Time. Times are formatted with the
+// layout in the `layout` struct tag, or time.RFC3339Nano if not given.
+//
+//	type myVersion struct {
+//		ID      int64     `version:"id"`
+//		Created time.Time `version:"created" layout:"2006-01-02"`
+//	}
+func VersionOf(v interface{}) (Version, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	version := Version{}
+	for _, field := range taggedFields(value.Type(), "version") {
+		s, err := formatVersionField(value.Field(field.index), field.structField)
+		if err != nil {
+			return nil, err
+		}
+		version[field.name] = s
+	}
+	return version, nil
+}
+
+// Decode converts the version to the struct pointed to by v, using the same
+// struct tags as VersionOf. Every tagged key must be present in the version,
+// otherwise a *MissingKeysError is returned.
+func (ver Version) Decode(v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	value = value.Elem()
+
+	fields := taggedFields(value.Type(), "version")
+	var missing []string
+	for _, field := range fields {
+		if _, ok := ver[field.name]; !ok {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return &MissingKeysError{Keys: missing}
+	}
+
+	for _, field := range fields {
+		err := parseVersionField(ver[field.name], value.Field(field.index), field.structField)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type taggedField struct {
+	name        string
+	index       int
+	structField reflect.StructField
+}
+
+// taggedFields returns the exported fields of a struct type which have the given
+// tag, skipping fields tagged with "-".
+func taggedFields(t reflect.Type, tag string) []taggedField {
+	var fields []taggedField
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(structField.Tag.Get(tag), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, taggedField{name, i, structField})
+	}
+	return fields
+}
+
+func timeLayout(structField reflect.StructField) string {
+	if layout := structField.Tag.Get("layout"); layout != "" {
+		return layout
+	}
+	return time.RFC3339Nano
+}
+
+func formatVersionField(value reflect.Value, structField reflect.StructField) (string, error) {
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(timeLayout(structField)), nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("field %s has unsupported type %s for a version",
+			structField.Name, value.Type())
+	}
+}
+
+func parseVersionField(s string, value reflect.Value, structField reflect.StructField) error {
+	if value.Type() == timeType {
+		t, err := time.Parse(timeLayout(structField), s)
+		if err != nil {
+			return versionKeyError(structField, err)
+		}
+		value.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return versionKeyError(structField, err)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return versionKeyError(structField, err)
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, value.Type().Bits())
+		if err != nil {
+			return versionKeyError(structField, err)
+		}
+		value.SetUint(u)
+	default:
+		return fmt.Errorf("field %s has unsupported type %s for a version",
+			structField.Name, value.Type())
+	}
+	return nil
+}
+
+func versionKeyError(structField reflect.StructField, err error) error {
+	key := strings.Split(structField.Tag.Get("version"), ",")[0]
+	return &KeyError{Kind: "version", Key: key, Err: err}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2018 Joseph Wright <joseph@cloudboss.co>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package ofcourse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testVersion struct {
+	ID       int64     `version:"id"`
+	Build    uint8     `version:"build"`
+	Name     string    `version:"name"`
+	Latest   bool      `version:"latest"`
+	Created  time.Time `version:"created"`
+	Day      time.Time `version:"day" layout:"2006-01-02"`
+	Ignored  string    `version:"-"`
+	Untagged string
+}
+
+func Test_VersionOf(t *testing.T) {
+	created := time.Date(2018, 10, 1, 12, 30, 0, 500, time.UTC)
+	v := testVersion{
+		ID:       9007199254740993,
+		Build:    7,
+		Name:     "noop",
+		Latest:   true,
+		Created:  created,
+		Day:      created,
+		Ignored:  "x",
+		Untagged: "y",
+	}
+	expected := Version{
+		"id":      "9007199254740993",
+		"build":   "7",
+		"name":    "noop",
+		"latest":  "true",
+		"created": "2018-10-01T12:30:00.0000005Z",
+		"day":     "2018-10-01",
+	}
+
+	version, err := VersionOf(v)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, version)
+
+	version, err = VersionOf(&v)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, version)
+
+	_, err = VersionOf("id")
+	assert.Equal(t, ErrNotStruct, err)
+
+	_, err = VersionOf(struct {
+		Tags []string `version:"tags"`
+	}{})
+	assert.EqualError(t, err, "field Tags has unsupported type []string for a version")
+}
+
+func Test_VersionDecode(t *testing.T) {
+	var v testVersion
+	err := Version{
+		"id":      "42",
+		"build":   "7",
+		"name":    "noop",
+		"latest":  "false",
+		"created": "2018-10-01T12:30:00Z",
+		"day":     "2018-10-01",
+	}.Decode(&v)
+	assert.Nil(t, err)
+	assert.Equal(t, testVersion{
+		ID:      42,
+		Build:   7,
+		Name:    "noop",
+		Created: time.Date(2018, 10, 1, 12, 30, 0, 0, time.UTC),
+		Day:     time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, v)
+
+	tests := []struct {
+		version Version
+		err     string
+	}{
+		{
+			Version{"id": "42", "name": "noop"},
+			`version is missing keys "build", "created", "day", "latest", it may be from an older release of the resource`,
+		},
+		{
+			Version{"id": "x", "build": "7", "name": "noop", "latest": "false",
+				"created": "2018-10-01T12:30:00Z", "day": "2018-10-01"},
+			`version key "id": strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			Version{"id": "1", "build": "256", "name": "noop", "latest": "false",
+				"created": "2018-10-01T12:30:00Z", "day": "2018-10-01"},
+			`version key "build": strconv.ParseUint: parsing "256": value out of range`,
+		},
+		{
+			Version{"id": "1", "build": "1", "name": "noop", "latest": "false",
+				"created": "2018-10-01T12:30:00Z", "day": "10/01/2018"},
+			`version key "day": parsing time "10/01/2018" as "2006-01-02": cannot parse "10/01/2018" as "2006"`,
+		},
+	}
+	for _, test := range tests {
+		var v testVersion
+		err := test.version.Decode(&v)
+		assert.EqualError(t, err, test.err)
+	}
+
+	err = Version{}.Decode(v)
+	assert.Equal(t, ErrNotStruct, err)
+}
@@ -42,6 +42,17 @@ var (
 // Resource implements the ofcourse.Resource interface.
 type Resource struct{}
 
+// Config implements the optional ofcourse.Configurable interface. Its `Outputs` declare the files
+// written by `In`, which ofcourse checks for after `In` returns. The same declaration is used by the
+// tests to verify the output directory, and to ensure the README lists the files under `in`.
+func (r *Resource) Config() oc.Config {
+	return oc.Config{
+		Outputs: oc.Outputs{
+			{Pattern: "version", Description: "The fetched version, as JSON."},
+		},
+	}
+}
+
 // Check implements the ofcourse.Resource Check method, corresponding to the /opt/resource/check command.
 // This is called when Concourse does its resource checks, or when the `fly check-resource` command is run.
 func (r *Resource) Check(source oc.Source, version oc.Version, env oc.Environment,
@@ -87,7 +98,8 @@ func (r *Resource) In(outputDirectory string, source oc.Source, params oc.Params
 	logger.Debugf("This is a debug message")
 
 	// Write the `version` argument to a file in the output directory,
-	// so the `Out` function can read it.
+	// so the `Out` function can read it. The file is written atomically,
+	// so that it is never left partially written if `In` is interrupted.
 	outputPath := fmt.Sprintf("%s/version", outputDirectory)
 	bytes, err := json.Marshal(version)
 	if err != nil {
@@ -95,7 +107,7 @@ func (r *Resource) In(outputDirectory string, source oc.Source, params oc.Params
 	}
 	logger.Debugf("Version: %s", string(bytes))
 
-	err = ioutil.WriteFile(outputPath, bytes, 0644)
+	err = oc.WriteFileAtomic(outputPath, bytes, 0644)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -94,10 +94,9 @@ func TestIn(t *testing.T) {
 		assert.Equal(t, tc.metadataOut, metadata)
 		assert.Equal(t, tc.err, err)
 
-		// Ensure the output file has been created
+		// Ensure the files declared in the resource's outputs have been created
+		assert.Nil(t, r.Config().Outputs.Verify(td, tc.paramsIn))
 		path := fmt.Sprintf("%s/version", td)
-		_, fileErr := os.Stat(path)
-		assert.False(t, os.IsNotExist(fileErr))
 
 		// Read the output file
 		bytes, err := ioutil.ReadFile(path)
@@ -110,6 +109,14 @@ func TestIn(t *testing.T) {
 	}
 }
 
+func TestReadmeOutputs(t *testing.T) {
+	// Ensure the README documents the files declared in the resource's outputs
+	readme, err := ioutil.ReadFile("../README.md")
+	assert.Nil(t, err)
+	r := Resource{}
+	assert.Contains(t, string(readme), r.Config().Outputs.Markdown())
+}
+
 func TestOut(t *testing.T) {
 	env := oc.NewEnvironment()
 	var testCases = []struct {